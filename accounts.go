@@ -2,17 +2,18 @@ package coinbase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
 // ListAccounts Lists current user’s accounts to which the authentication method has access to.
 // Endpoint: GET /accounts
-func (c *Client) ListAccounts(ctx context.Context) (*[]Account, *Pagination, error) {
+func (c *Client) ListAccounts(ctx context.Context, opts *ListOptions) (*[]Account, *Pagination, error) {
 	accounts := &[]Account{}
 
 	pagination := &Pagination{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s", c.APIBase, "accounts"), nil)
+	req, err := c.NewRequest(ctx, "GET", withListOptions(fmt.Sprintf("%s/%s", c.APIBase, "accounts"), opts), nil)
 	if err != nil {
 		return accounts, pagination, err
 	}
@@ -24,6 +25,28 @@ func (c *Client) ListAccounts(ctx context.Context) (*[]Account, *Pagination, err
 	return accounts, pagination, nil
 }
 
+// IterateAccounts Returns an Iterator over all current user’s accounts, following pagination automatically.
+// Every Value is an Account.
+func (c *Client) IterateAccounts(ctx context.Context, opts *ListOptions) *Iterator {
+	return c.newIterator(ctx, withListOptions(fmt.Sprintf("%s/%s", c.APIBase, "accounts"), opts), func(data []byte) (interface{}, error) {
+		account := Account{}
+		err := json.Unmarshal(data, &account)
+		return account, err
+	})
+}
+
+// ListAllAccounts Lists all current user’s accounts, fetching every page.
+func (c *Client) ListAllAccounts(ctx context.Context, opts *ListOptions) (*[]Account, error) {
+	accounts := &[]Account{}
+
+	it := c.IterateAccounts(ctx, opts)
+	for it.Next() {
+		*accounts = append(*accounts, it.Value().(Account))
+	}
+
+	return accounts, it.Err()
+}
+
 // GetAccount Show current user’s account.
 // Endpoint: GET /accounts/:account_id
 func (c *Client) GetAccount(ctx context.Context, accountID string) (*Account, error) {
@@ -0,0 +1,271 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Address book errors
+var (
+	ErrContactNotFound     = errors.New("coinbase: contact not found")
+	ErrInvalidContact      = errors.New("coinbase: invalid contact")
+	ErrIncompatibleContact = errors.New("coinbase: contact incompatible with the account")
+	ErrUnknownDestination  = errors.New("coinbase: destination not in the address book")
+)
+
+// MemoryAddressBook is an AddressBookStore keeping contacts in memory.
+type MemoryAddressBook struct {
+	mu       sync.Mutex
+	contacts map[string]Contact
+}
+
+// NewMemoryAddressBook returns an empty MemoryAddressBook.
+func NewMemoryAddressBook() *MemoryAddressBook {
+	return &MemoryAddressBook{contacts: map[string]Contact{}}
+}
+
+// List returns every contact, sorted by name
+func (m *MemoryAddressBook) List(ctx context.Context) ([]Contact, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return sortContacts(m.contacts), nil
+}
+
+// Get returns the contact named name
+func (m *MemoryAddressBook) Get(ctx context.Context, name string) (*Contact, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	contact, ok := m.contacts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrContactNotFound, name)
+	}
+
+	return &contact, nil
+}
+
+// Put adds or replaces the contact with the same name
+func (m *MemoryAddressBook) Put(ctx context.Context, contact Contact) error {
+	if err := contact.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.contacts[contact.Name] = contact
+
+	return nil
+}
+
+// Delete removes the contact named name
+func (m *MemoryAddressBook) Delete(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.contacts[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrContactNotFound, name)
+	}
+
+	delete(m.contacts, name)
+
+	return nil
+}
+
+// FileAddressBook is an AddressBookStore persisting contacts in a JSON file.
+// It is safe for concurrent use within a process, not across processes.
+type FileAddressBook struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileAddressBook returns a FileAddressBook stored at path, the file is created on the first Put.
+func NewFileAddressBook(path string) *FileAddressBook {
+	return &FileAddressBook{path: path}
+}
+
+// List returns every contact, sorted by name
+func (f *FileAddressBook) List(ctx context.Context) ([]Contact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	contacts, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return sortContacts(contacts), nil
+}
+
+// Get returns the contact named name
+func (f *FileAddressBook) Get(ctx context.Context, name string) (*Contact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	contacts, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	contact, ok := contacts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrContactNotFound, name)
+	}
+
+	return &contact, nil
+}
+
+// Put adds or replaces the contact with the same name
+func (f *FileAddressBook) Put(ctx context.Context, contact Contact) error {
+	if err := contact.Validate(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	contacts, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	contacts[contact.Name] = contact
+
+	return writeJSONFile(f.path, contacts)
+}
+
+// Delete removes the contact named name
+func (f *FileAddressBook) Delete(ctx context.Context, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	contacts, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := contacts[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrContactNotFound, name)
+	}
+
+	delete(contacts, name)
+
+	return writeJSONFile(f.path, contacts)
+}
+
+// load reads the contacts from the file, a missing file holds no contacts
+func (f *FileAddressBook) load() (map[string]Contact, error) {
+	contacts := map[string]Contact{}
+
+	if err := readJSONFile(f.path, &contacts); err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+// sortContacts returns the contacts sorted by name
+func sortContacts(contacts map[string]Contact) []Contact {
+	sorted := make([]Contact, 0, len(contacts))
+	for _, contact := range contacts {
+		sorted = append(sorted, contact)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	return sorted
+}
+
+// Validate checks that the contact has a name, a currency and an address.
+func (ct Contact) Validate() error {
+	if ct.Name == "" || ct.Currency == "" || ct.Address == "" {
+		return fmt.Errorf("%w: Name, Currency and Address are required", ErrInvalidContact)
+	}
+
+	return nil
+}
+
+// matches reports whether a send to address, with the given network and destination tag, goes to the contact.
+// A destination tag set on the contact must be repeated, sends to exchanges are lost without it.
+func (ct Contact) matches(address string, network string, destinationTag string) bool {
+	sameAddress := ct.Address == address || (strings.Contains(address, "@") && strings.EqualFold(ct.Address, address))
+
+	switch {
+	case !sameAddress:
+		return false
+	case ct.DestinationTag != "" && ct.DestinationTag != destinationTag:
+		return false
+	case ct.Network != "" && network != "" && !strings.EqualFold(ct.Network, network):
+		return false
+	}
+
+	return true
+}
+
+// SendToContact sends funds from an account to the contact named name of the AddressBook.
+// To, Network and DestinationTag of sendData are taken from the contact, Currency defaults to the one of the account.
+// It fails with ErrIncompatibleContact if the contact holds a different currency than the account or sendData.
+func (c *Client) SendToContact(ctx context.Context, accountID string, name string, sendData SendMoney) (*Transaction, error) {
+	if c.AddressBook == nil {
+		return nil, fmt.Errorf("%w: no address book configured", ErrContactNotFound)
+	}
+
+	contact, err := c.AddressBook.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := c.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(account.Currency, contact.Currency) {
+		return nil, fmt.Errorf("%w: %s holds %s, account %s holds %s", ErrIncompatibleContact, contact.Name, contact.Currency, accountID, account.Currency)
+	}
+
+	if sendData.Currency != "" && !strings.EqualFold(sendData.Currency, contact.Currency) {
+		return nil, fmt.Errorf("%w: %s holds %s, not %s", ErrIncompatibleContact, contact.Name, contact.Currency, sendData.Currency)
+	}
+
+	if sendData.Type == "" {
+		sendData.Type = string(TransactionTypeSend)
+	}
+	if sendData.Currency == "" {
+		sendData.Currency = account.Currency
+	}
+	sendData.To = contact.Address
+	sendData.Network = contact.Network
+	sendData.DestinationTag = contact.DestinationTag
+
+	return c.SendMoney(ctx, accountID, sendData)
+}
+
+// checkDestination refuses sends to destinations not in the AddressBook, when StrictAddressBook is set
+func (c *Client) checkDestination(ctx context.Context, sendData SendMoney) error {
+	if !c.StrictAddressBook {
+		return nil
+	}
+
+	if c.AddressBook == nil {
+		return fmt.Errorf("%w: no address book configured", ErrUnknownDestination)
+	}
+
+	contacts, err := c.AddressBook.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, contact := range contacts {
+		if contact.matches(sendData.To, sendData.Network, sendData.DestinationTag) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownDestination, sendData.To)
+}
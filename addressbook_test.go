@@ -0,0 +1,83 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testContactAddress = "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+
+// newContactClient returns a client of s sending to a BTC contact named exchange from the BTC account a1
+func newContactClient(t *testing.T, s *signatureServer) *Client {
+	c := s.client()
+	c.AddressBook = NewMemoryAddressBook()
+
+	if err := c.AddressBook.Put(context.Background(), Contact{Name: "exchange", Currency: "BTC", Address: testContactAddress}); err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func respondAccount(attempt int, w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		w.Write([]byte(`{"data":{"id":"a1","currency":"BTC"}}`))
+		return
+	}
+
+	w.Write([]byte(`{"data":{"id":"t1","type":"send"}}`))
+}
+
+func TestSendToContact(t *testing.T) {
+	s := newSignatureServer(t, respondAccount)
+	defer s.Close()
+	c := newContactClient(t, s)
+
+	if _, err := c.SendToContact(context.Background(), "a1", "exchange", SendMoney{Amount: MustParseDecimal("0.1")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.bodies) != 2 {
+		t.Fatalf("%d requests, want 2", len(s.bodies))
+	}
+	for _, field := range []string{`"to":"` + testContactAddress + `"`, `"currency":"BTC"`, `"type":"send"`} {
+		if !strings.Contains(s.bodies[1], field) {
+			t.Errorf("send %s without %s", s.bodies[1], field)
+		}
+	}
+}
+
+func TestSendToContactCurrency(t *testing.T) {
+	s := newSignatureServer(t, respondAccount)
+	defer s.Close()
+	c := newContactClient(t, s)
+
+	_, err := c.SendToContact(context.Background(), "a1", "exchange", SendMoney{Amount: MustParseDecimal("0.1"), Currency: "ETH"})
+	if !errors.Is(err, ErrIncompatibleContact) {
+		t.Fatalf("%v, want %v", err, ErrIncompatibleContact)
+	}
+
+	// The account is read, nothing is sent
+	if len(s.bodies) != 1 {
+		t.Errorf("%d requests, want 1", len(s.bodies))
+	}
+
+	if _, err = c.SendToContact(context.Background(), "a1", "exchange", SendMoney{Amount: MustParseDecimal("0.1"), Currency: "btc"}); err != nil {
+		t.Errorf("same currency in another case: %v", err)
+	}
+}
+
+func TestSendToContactAccountCurrency(t *testing.T) {
+	s := newSignatureServer(t, func(attempt int, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"id":"a1","currency":"ETH"}}`))
+	})
+	defer s.Close()
+	c := newContactClient(t, s)
+
+	if _, err := c.SendToContact(context.Background(), "a1", "exchange", SendMoney{Amount: MustParseDecimal("0.1")}); !errors.Is(err, ErrIncompatibleContact) {
+		t.Fatalf("%v, want %v", err, ErrIncompatibleContact)
+	}
+}
@@ -2,17 +2,18 @@ package coinbase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
 // ListAddresses Lists addresses for an account.
 // Endpoint: GET /accounts/:account_id/addresses
-func (c *Client) ListAddresses(ctx context.Context, accountID string) (*[]Address, *Pagination, error) {
+func (c *Client) ListAddresses(ctx context.Context, accountID string, opts *ListOptions) (*[]Address, *Pagination, error) {
 	addresses := &[]Address{}
 
 	pagination := &Pagination{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "addresses"), nil)
+	req, err := c.NewRequest(ctx, "GET", withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "addresses"), opts), nil)
 	if err != nil {
 		return addresses, pagination, err
 	}
@@ -24,6 +25,28 @@ func (c *Client) ListAddresses(ctx context.Context, accountID string) (*[]Addres
 	return addresses, pagination, nil
 }
 
+// IterateAddresses Returns an Iterator over all addresses for an account, following pagination automatically.
+// Every Value is an Address.
+func (c *Client) IterateAddresses(ctx context.Context, accountID string, opts *ListOptions) *Iterator {
+	return c.newIterator(ctx, withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "addresses"), opts), func(data []byte) (interface{}, error) {
+		address := Address{}
+		err := json.Unmarshal(data, &address)
+		return address, err
+	})
+}
+
+// ListAllAddresses Lists all addresses for an account, fetching every page.
+func (c *Client) ListAllAddresses(ctx context.Context, accountID string, opts *ListOptions) (*[]Address, error) {
+	addresses := &[]Address{}
+
+	it := c.IterateAddresses(ctx, accountID, opts)
+	for it.Next() {
+		*addresses = append(*addresses, it.Value().(Address))
+	}
+
+	return addresses, it.Err()
+}
+
 // ShowAddress Show an individual address for an account.
 // Endpoint: GET /accounts/:account_id/addresses/:address_id
 func (c *Client) ShowAddress(ctx context.Context, accountID string, addressID string) (*Address, error) {
@@ -43,12 +66,12 @@ func (c *Client) ShowAddress(ctx context.Context, accountID string, addressID st
 
 // ListAddressTransactions List transactions that have been sent to a specific address.
 // Endpoint: GET /accounts/:account_id/addresses/:address_id/transactions
-func (c *Client) ListAddressTransactions(ctx context.Context, accountID string, addressID string) (*[]Transaction, *Pagination, error) {
+func (c *Client) ListAddressTransactions(ctx context.Context, accountID string, addressID string, opts *ListOptions) (*[]Transaction, *Pagination, error) {
 	addresses := &[]Transaction{}
 
 	pagination := &Pagination{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "addresses", addressID, "transactions"), nil)
+	req, err := c.NewRequest(ctx, "GET", withListOptions(fmt.Sprintf("%s/%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "addresses", addressID, "transactions"), opts), nil)
 	if err != nil {
 		return addresses, pagination, err
 	}
@@ -60,6 +83,28 @@ func (c *Client) ListAddressTransactions(ctx context.Context, accountID string,
 	return addresses, pagination, nil
 }
 
+// IterateAddressTransactions Returns an Iterator over all transactions that have been sent to a specific address, following pagination automatically.
+// Every Value is a Transaction.
+func (c *Client) IterateAddressTransactions(ctx context.Context, accountID string, addressID string, opts *ListOptions) *Iterator {
+	return c.newIterator(ctx, withListOptions(fmt.Sprintf("%s/%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "addresses", addressID, "transactions"), opts), func(data []byte) (interface{}, error) {
+		transaction := Transaction{}
+		err := json.Unmarshal(data, &transaction)
+		return transaction, err
+	})
+}
+
+// ListAllAddressTransactions Lists all transactions that have been sent to a specific address, fetching every page.
+func (c *Client) ListAllAddressTransactions(ctx context.Context, accountID string, addressID string, opts *ListOptions) (*[]Transaction, error) {
+	addressTransactions := &[]Transaction{}
+
+	it := c.IterateAddressTransactions(ctx, accountID, addressID, opts)
+	for it.Next() {
+		*addressTransactions = append(*addressTransactions, it.Value().(Transaction))
+	}
+
+	return addressTransactions, it.Err()
+}
+
 // CreateAddress Creates a new address for an account.
 // Endpoint: POST /accounts/:account_id/addresses
 func (c *Client) CreateAddress(ctx context.Context, accountID string, addressData CreateAddress) (*Address, error) {
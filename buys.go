@@ -2,17 +2,18 @@ package coinbase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
 // ListBuys Lists buys for an account.
 // Endpoint: GET /accounts/:account_id/buys
-func (c *Client) ListBuys(ctx context.Context, accountID string) (*[]Buy, *Pagination, error) {
+func (c *Client) ListBuys(ctx context.Context, accountID string, opts *ListOptions) (*[]Buy, *Pagination, error) {
 	buys := &[]Buy{}
 
 	pagination := &Pagination{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "buys"), nil)
+	req, err := c.NewRequest(ctx, "GET", withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "buys"), opts), nil)
 	if err != nil {
 		return buys, pagination, err
 	}
@@ -24,12 +25,35 @@ func (c *Client) ListBuys(ctx context.Context, accountID string) (*[]Buy, *Pagin
 	return buys, pagination, nil
 }
 
+// IterateBuys Returns an Iterator over all buys for an account, following pagination automatically.
+// Every Value is a Buy.
+func (c *Client) IterateBuys(ctx context.Context, accountID string, opts *ListOptions) *Iterator {
+	return c.newIterator(ctx, withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "buys"), opts), func(data []byte) (interface{}, error) {
+		buy := Buy{}
+		err := json.Unmarshal(data, &buy)
+		return buy, err
+	})
+}
+
+// ListAllBuys Lists all buys for an account, fetching every page.
+func (c *Client) ListAllBuys(ctx context.Context, accountID string, opts *ListOptions) (*[]Buy, error) {
+	buys := &[]Buy{}
+
+	it := c.IterateBuys(ctx, accountID, opts)
+	for it.Next() {
+		*buys = append(*buys, it.Value().(Buy))
+	}
+
+	return buys, it.Err()
+}
+
 // GetBuy Show an individual buy.
+// Related resources listed in expand, e.g. ExpandAll, are embedded in the response.
 // Endpoint: GET /accounts/:account_id/buys/:buy_id
-func (c *Client) GetBuy(ctx context.Context, accountID string, buyID string) (*Buy, error) {
+func (c *Client) GetBuy(ctx context.Context, accountID string, buyID string, expand ...string) (*Buy, error) {
 	buy := &Buy{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "buys", buyID), nil)
+	req, err := c.NewRequest(ctx, "GET", withExpand(fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "buys", buyID), expand), nil)
 	if err != nil {
 		return buy, err
 	}
@@ -51,7 +75,16 @@ func (c *Client) PlaceBuy(ctx context.Context, accountID string, buyData PlaceBu
 		return buy, err
 	}
 
-	if err = c.SendWithAuth(req, buy); err != nil {
+	send := func() error { return c.SendWithAuth(req, buy) }
+
+	// Quotes do not move money
+	if buyData.Quote {
+		err = send()
+	} else {
+		err = c.guard(ctx, buyAction(accountID, buyData), send)
+	}
+
+	if err != nil {
 		return buy, err
 	}
 
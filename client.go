@@ -7,6 +7,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -15,6 +16,10 @@ import (
 	"time"
 )
 
+// ErrInvalidResponse is returned when a successful response cannot be decoded
+var ErrInvalidResponse = errors.New("coinbase: invalid response")
+
+// NewClient returns a Client authenticating with API Key and Secret
 func NewClient(APIKey string, APISecret string) (*Client) {
 
 	return &Client{
@@ -25,6 +30,16 @@ func NewClient(APIKey string, APISecret string) (*Client) {
 	}
 }
 
+// NewClientWithAuthenticator returns a Client authenticating requests with the given Authenticator, e.g. OAuth2Auth
+func NewClientWithAuthenticator(authenticator Authenticator) *Client {
+
+	return &Client{
+		HTTPClient:    &http.Client{},
+		APIBase:       APIBase,
+		Authenticator: authenticator,
+	}
+}
+
 // SetLog will set/change the output destination.
 func (c *Client) SetLog(log io.Writer) {
 	c.Log = log
@@ -34,6 +49,83 @@ func (c *Client) SetLog(log io.Writer) {
 // unmarshaled into v, or if v is an io.Writer, the response will
 // be written to it without decoding
 func (c *Client) Send(req *http.Request, v ...interface{}) error {
+	return c.do(req, false, v...)
+}
+
+// SendWithAuth makes a request to the API and apply Authentication headers automatically.
+func (c *Client) SendWithAuth(req *http.Request, v ...interface{}) error {
+	return c.do(req, true, v...)
+}
+
+// do sends the request, retrying it according to RetryPolicy.
+// When auth is true every attempt is signed again, so that each one carries a fresh timestamp.
+func (c *Client) do(req *http.Request, auth bool, v ...interface{}) error {
+	if token := twoFactorToken(req.Context()); token != "" {
+		req.Header.Set("CB-2FA-TOKEN", token)
+	}
+
+	resynced, twoFactorAsked := false, false
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			if err := rewindBody(req); err != nil {
+				return err
+			}
+		}
+
+		limiter := c.limiter(auth)
+		if err := limiter.Wait(req.Context()); err != nil {
+			return err
+		}
+
+		if auth {
+			if err := c.authenticate(req); err != nil {
+				return err
+			}
+		}
+
+		resp, err := c.send(req, v...)
+		limiter.observe(resp)
+
+		// Re-issue the identical request, same body and idempotency key, with the token asked to the provider
+		if !twoFactorAsked && c.needsTwoFactor(req, err) {
+			twoFactorAsked = true
+
+			token, providerErr := c.TwoFactorProvider(req.Context(), req)
+			if providerErr != nil {
+				return providerErr
+			}
+
+			// No token given, the request fails with two_factor_required
+			if token == "" {
+				return err
+			}
+
+			req.Header.Set("CB-2FA-TOKEN", token)
+			continue
+		}
+
+		// Timestamps rejected by the server are signed again after measuring the clock offset
+		if auth && !resynced && c.clockRejected(err) {
+			resynced = true
+			if c.SyncClock(req.Context()) == nil {
+				continue
+			}
+		}
+
+		delay, retry := c.RetryPolicy.retry(req, attempt, err)
+		if !retry {
+			return err
+		}
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return err
+		}
+	}
+}
+
+// send performs a single attempt of the request, the returned response has its body already consumed
+func (c *Client) send(req *http.Request, v ...interface{}) (*http.Response, error) {
 	var (
 		err  error
 		resp *http.Response
@@ -53,56 +145,93 @@ func (c *Client) Send(req *http.Request, v ...interface{}) error {
 	c.log(req, resp)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		errResp := &ErrorResponse{Response: resp}
+		errResp := &ErrorResponse{
+			Response:   resp,
+			StatusCode: resp.StatusCode,
+			RequestID:  requestID(resp),
+		}
 		data, err = ioutil.ReadAll(resp.Body)
 
 		if err == nil && len(data) > 0 {
+			errResp.Body = data
 			json.Unmarshal(data, errResp)
 		}
 
-		return errResp
+		return resp, errResp
 	}
 	if v == nil {
-		return nil
+		return resp, nil
 	}
 
 	if w, ok := v[0].(io.Writer); ok {
 		io.Copy(w, resp.Body)
-		return nil
+		return resp, nil
 	}
 
 	r := Response{}
 
-	json.NewDecoder(resp.Body).Decode(&r)
+	// An empty body leaves v untouched
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil && err != io.EOF {
+		return resp, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
 
-	dataByte, _ := json.Marshal(&r.Data)
-	_ = json.Unmarshal(dataByte, &v[0])
+	dataByte, err := json.Marshal(&r.Data)
+	if err == nil {
+		err = json.Unmarshal(dataByte, &v[0])
+	}
+	if err != nil {
+		return resp, fmt.Errorf("%w: data: %v", ErrInvalidResponse, err)
+	}
 
 	if len(v) > 1{
-		paginationByte, _ := json.Marshal(&r.Pagination)
-		_ = json.Unmarshal(paginationByte, &v[1])
+		paginationByte, err := json.Marshal(&r.Pagination)
+		if err == nil {
+			err = json.Unmarshal(paginationByte, &v[1])
+		}
+		if err != nil {
+			return resp, fmt.Errorf("%w: pagination: %v", ErrInvalidResponse, err)
+		}
 	}
 
-	return nil
+	return resp, nil
 }
 
-// SendWithAuth makes a request to the API and apply Authentication headers automatically.
-func (c *Client) SendWithAuth(req *http.Request, v ...interface{}) error {
+// authenticate applies Authentication headers to the request
+func (c *Client) authenticate(req *http.Request) error {
+	return c.authenticator().Authenticate(req, c.now(req.Context()))
+}
 
-	timestamp := time.Now().Unix()
+// authenticator returns the Authenticator of the client, API Key authentication is used when none is set
+func (c *Client) authenticator() Authenticator {
+	if c.Authenticator != nil {
+		return c.Authenticator
+	}
+
+	return &APIKeyAuth{Key: c.APIKey, Secret: c.APISecret}
+}
+
+// Authenticate applies API Key authentication headers to the request
+func (a *APIKeyAuth) Authenticate(req *http.Request, now time.Time) error {
+
+	timestamp := now.Unix()
+
+	signature, err := a.generateSignature(req, timestamp)
+	if err != nil {
+		return err
+	}
 
-	req.Header.Set("CB-ACCESS-KEY", c.APIKey)
+	req.Header.Set("CB-ACCESS-KEY", a.Key)
 
-	req.Header.Set("CB-ACCESS-SIGN", c.generateSignature(req, timestamp))
+	req.Header.Set("CB-ACCESS-SIGN", signature)
 
 	req.Header.Set("CB-ACCESS-TIMESTAMP", fmt.Sprintf("%d", timestamp))
 
-	return c.Send(req, v...)
+	return nil
 }
 
 // NewRequest constructs a request
@@ -139,24 +268,52 @@ func (c *Client) log(r *http.Request, resp *http.Response) {
 }
 
 // generateSignature will generate proper signature https://developers.coinbase.com/api/v2#api-key
-func (c *Client) generateSignature(req *http.Request, timestamp int64) string {
+func (a *APIKeyAuth) generateSignature(req *http.Request, timestamp int64) (string, error) {
 
 	nonce := fmt.Sprintf("%d", timestamp)
 
-	var body string
-
-	if req.Body != nil {
-		buf := new(bytes.Buffer)
-		buf.ReadFrom(req.Body)
-		body = buf.String()
+	body, err := requestBody(req)
+	if err != nil {
+		return "", err
 	}
 
-	message := nonce + req.Method + req.URL.Path + body //As per Coinbase Documentation
+	message := nonce + req.Method + req.URL.RequestURI() + string(body) //As per Coinbase Documentation, path includes the query string
 
-	h := hmac.New(sha256.New, []byte(c.APISecret))
+	h := hmac.New(sha256.New, []byte(a.Secret))
 	h.Write([]byte(message))
 
 	signature := hex.EncodeToString(h.Sum(nil))
 
-	return signature
+	return signature, nil
+}
+
+// requestBody returns a copy of the request body without consuming it.
+// Requests built without GetBody are buffered once, so that they can be signed and sent again.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody == nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		}
+		req.Body, _ = req.GetBody()
+
+		return data, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return ioutil.ReadAll(body)
 }
@@ -0,0 +1,267 @@
+package coinbase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAPIKey    = "key"
+	testAPISecret = "secret"
+)
+
+func TestAPIKeySignatureVectors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		want   string
+	}{
+		{
+			name:   "GET with query",
+			method: "GET",
+			url:    "https://api.coinbase.com/v2/accounts/abc/transactions?limit=25&order=asc",
+			want:   "5d5c37781788dbb64e4c9b27dbbcca24e3a4e6763958b3a33d93415609fbc68e",
+		},
+		{
+			name:   "POST with body",
+			method: "POST",
+			url:    "https://api.coinbase.com/v2/accounts/abc/transactions",
+			body:   `{"type":"send","to":"user@example.com","amount":"0.1","currency":"BTC"}`,
+			want:   "46385bdda072d8c6b35da4912240c20d0650748ce9cc209f92f4e930565d4dfe",
+		},
+		{
+			name:   "no body",
+			method: "DELETE",
+			url:    "https://api.coinbase.com/v2/accounts/abc",
+			want:   "3fc5a9822d6fc13fe913bafe47d123b3d0c7dd9a55fe43dab56c8a8e0009910a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, test.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.body != "" {
+				// Without GetBody, as built by callers of Send
+				req.Body = ioutil.NopCloser(strings.NewReader(test.body))
+			}
+
+			auth := &APIKeyAuth{Key: testAPIKey, Secret: testAPISecret}
+			if err = auth.Authenticate(req, time.Unix(1700000000, 0)); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := req.Header.Get("CB-ACCESS-SIGN"); got != test.want {
+				t.Errorf("CB-ACCESS-SIGN = %s, want %s", got, test.want)
+			}
+			if got := req.Header.Get("CB-ACCESS-TIMESTAMP"); got != "1700000000" {
+				t.Errorf("CB-ACCESS-TIMESTAMP = %s, want 1700000000", got)
+			}
+			if got := req.Header.Get("CB-ACCESS-KEY"); got != testAPIKey {
+				t.Errorf("CB-ACCESS-KEY = %s, want %s", got, testAPIKey)
+			}
+
+			if req.Body != nil {
+				body, _ := ioutil.ReadAll(req.Body)
+				if string(body) != test.body {
+					t.Errorf("body after signing = %q, want %q", body, test.body)
+				}
+			}
+		})
+	}
+}
+
+// signatureServer verifies the API Key signature of every request, then lets respond answer it
+type signatureServer struct {
+	*httptest.Server
+	t       *testing.T
+	mu      sync.Mutex
+	bodies  []string
+	respond func(attempt int, w http.ResponseWriter, r *http.Request)
+}
+
+func newSignatureServer(t *testing.T, respond func(attempt int, w http.ResponseWriter, r *http.Request)) *signatureServer {
+	s := &signatureServer{t: t, respond: respond}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+func (s *signatureServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	h := hmac.New(sha256.New, []byte(testAPISecret))
+	h.Write([]byte(r.Header.Get("CB-ACCESS-TIMESTAMP") + r.Method + r.URL.RequestURI() + string(body)))
+	want := hex.EncodeToString(h.Sum(nil))
+
+	if r.Header.Get("CB-ACCESS-KEY") != testAPIKey || r.Header.Get("CB-ACCESS-SIGN") != want {
+		s.t.Errorf("%s %s: invalid signature %s, want %s", r.Method, r.URL.RequestURI(), r.Header.Get("CB-ACCESS-SIGN"), want)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	s.bodies = append(s.bodies, string(body))
+	attempt := len(s.bodies)
+	s.mu.Unlock()
+
+	s.respond(attempt, w, r)
+}
+
+func (s *signatureServer) client() *Client {
+	c := NewClient(testAPIKey, testAPISecret)
+	c.APIBase = s.URL
+
+	return c
+}
+
+func respondOK(attempt int, w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"data":{}}`))
+}
+
+func TestSignatureVerifiedByServer(t *testing.T) {
+	s := newSignatureServer(t, respondOK)
+	defer s.Close()
+	c := s.client()
+
+	req, err := c.NewRequest(context.Background(), "GET", c.APIBase+"/accounts/abc/transactions?limit=25&order=asc", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SendWithAuth(req, &struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err = c.NewRequest(context.Background(), "POST", c.APIBase+"/accounts/abc/transactions?idem=1", SendMoney{Type: "send", To: "user@example.com", Currency: "BTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SendWithAuth(req, &struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.bodies) != 2 || s.bodies[0] != "" || !strings.Contains(s.bodies[1], `"to":"user@example.com"`) {
+		t.Fatalf("bodies = %q", s.bodies)
+	}
+}
+
+func TestSignedBodyDeliveredAfterRetry(t *testing.T) {
+	s := newSignatureServer(t, func(attempt int, w http.ResponseWriter, r *http.Request) {
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		respondOK(attempt, w, r)
+	})
+	defer s.Close()
+	c := s.client()
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, RetryNonIdempotent: true}
+
+	req, err := c.NewRequest(context.Background(), "POST", c.APIBase+"/accounts/abc/transactions", SendMoney{Type: "send", To: "user@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SendWithAuth(req, &struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.bodies) != 2 || s.bodies[0] == "" || s.bodies[1] != s.bodies[0] {
+		t.Fatalf("bodies = %q", s.bodies)
+	}
+}
+
+func TestSignedBodyDeliveredAfterTwoFactor(t *testing.T) {
+	s := newSignatureServer(t, func(attempt int, w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("CB-2FA-TOKEN") != "123456" {
+			w.WriteHeader(http.StatusPaymentRequired)
+			w.Write([]byte(`{"errors":[{"id":"two_factor_required","message":"Two-step verification code required"}]}`))
+			return
+		}
+		respondOK(attempt, w, r)
+	})
+	defer s.Close()
+	c := s.client()
+	c.TwoFactorProvider = func(ctx context.Context, req *http.Request) (string, error) {
+		return "123456", nil
+	}
+
+	req, err := c.NewRequest(context.Background(), "POST", c.APIBase+"/accounts/abc/transactions", SendMoney{Type: "send", To: "user@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SendWithAuth(req, &struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.bodies) != 2 || s.bodies[0] == "" || s.bodies[1] != s.bodies[0] {
+		t.Fatalf("bodies = %q", s.bodies)
+	}
+}
+
+func TestEmptyTwoFactorToken(t *testing.T) {
+	s := newSignatureServer(t, func(attempt int, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"errors":[{"id":"two_factor_required","message":"Two-step verification code required"}]}`))
+	})
+	defer s.Close()
+	c := s.client()
+
+	asked := 0
+	c.TwoFactorProvider = func(ctx context.Context, req *http.Request) (string, error) {
+		asked++
+		return "", nil
+	}
+
+	req, err := c.NewRequest(context.Background(), "POST", c.APIBase+"/accounts/abc/transactions", SendMoney{Type: "send", To: "user@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SendWithAuth(req, &struct{}{}); !errors.Is(err, ErrTwoFactorRequired) {
+		t.Fatalf("err = %v, want %v", err, ErrTwoFactorRequired)
+	}
+
+	if asked != 1 || len(s.bodies) != 1 {
+		t.Fatalf("provider asked %d times, request sent %d times, want once", asked, len(s.bodies))
+	}
+}
+
+func TestUndecodableResponse(t *testing.T) {
+	tests := []string{
+		`{"data":{"id":1}}`,
+		`{"data":{"id":"a1"},"pagination":{"limit":"ten"}}`,
+		`{"data":`,
+	}
+
+	for _, body := range tests {
+		body := body
+		s := newSignatureServer(t, func(attempt int, w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		})
+		c := s.client()
+		c.RetryPolicy = DefaultRetryPolicy()
+
+		accounts := []Account{}
+		if _, err := c.ListPage(context.Background(), c.APIBase+"/accounts", &accounts); !errors.Is(err, ErrInvalidResponse) {
+			t.Errorf("%s: %v, want %v", body, err, ErrInvalidResponse)
+		}
+		if len(s.bodies) != 1 {
+			t.Errorf("%s: sent %d times, want once", body, len(s.bodies))
+		}
+
+		s.Close()
+	}
+}
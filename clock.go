@@ -0,0 +1,101 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultClockSyncInterval is a sensible value for Client.ClockSyncInterval
+const DefaultClockSyncInterval = 10 * time.Minute
+
+// clockOffset holds the measured difference between the server and the local clock
+type clockOffset struct {
+	mu     sync.Mutex
+	offset time.Duration
+	synced time.Time
+}
+
+// localClock returns the Clock of the client, the system clock when not set
+func (c *Client) localClock() Clock {
+	if c.Clock == nil {
+		return systemClock{}
+	}
+
+	return c.Clock
+}
+
+// SyncClock measures the offset between the local clock and the API server time,
+// signed timestamps are corrected by it from then on.
+func (c *Client) SyncClock(ctx context.Context) error {
+	start := c.localClock().Now()
+
+	serverTime, err := c.GetTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	end := c.localClock().Now()
+
+	// The server time is compared with the middle of the round trip
+	local := start.Add(end.Sub(start) / 2)
+	offset := time.Unix(serverTime.Epoch, 0).Sub(local.Truncate(time.Second))
+
+	c.clock.mu.Lock()
+	c.clock.offset = offset
+	c.clock.synced = end
+	c.clock.mu.Unlock()
+
+	return nil
+}
+
+// ClockOffset returns the last measured offset of the server clock from the local one.
+func (c *Client) ClockOffset() time.Duration {
+	c.clock.mu.Lock()
+	defer c.clock.mu.Unlock()
+
+	return c.clock.offset
+}
+
+// now returns the current time corrected by the clock offset, measuring it when due.
+// If the measurement fails the last known offset is used until the next interval.
+func (c *Client) now(ctx context.Context) time.Time {
+	if c.ClockSyncInterval > 0 {
+		c.clock.mu.Lock()
+		local := c.localClock().Now()
+		due := c.clock.synced.IsZero() || local.Sub(c.clock.synced) >= c.ClockSyncInterval
+		if due {
+			// Claimed here so that concurrent requests do not measure it again
+			c.clock.synced = local
+		}
+		c.clock.mu.Unlock()
+
+		if due {
+			c.SyncClock(ctx)
+		}
+	}
+
+	return c.localClock().Now().Add(c.ClockOffset())
+}
+
+// clockRejected reports whether err is an authentication error caused by the request timestamp
+func (c *Client) clockRejected(err error) bool {
+	if c.ClockSyncInterval <= 0 || !errors.Is(err, ErrAuthentication) {
+		return false
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+
+	for _, e := range errResp.Errors {
+		if strings.Contains(strings.ToLower(e.Message), "timestamp") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// ErrUnknownCurrency is returned when converting from or to a currency without exchange rate
+var ErrUnknownCurrency = errors.New("coinbase: no exchange rate for currency")
+
+// Converter converts amounts between currencies offline, using a snapshot of exchange rates.
+// Arithmetic is exact, cross rates are computed through the base currency of the snapshot.
+type Converter struct {
+	base      string
+	rates     map[string]*big.Rat
+	invalid   map[string]string // Rates which could not be parsed, by currency
+	timestamp time.Time
+}
+
+// NewConverter returns a Converter using exchangeRates, taken at timestamp.
+// Invalid rates are skipped, converting from or to their currency fails with ErrUnknownCurrency.
+func NewConverter(exchangeRates *ExchangeRates, timestamp time.Time) (*Converter, error) {
+	if exchangeRates == nil || exchangeRates.Currency == "" {
+		return nil, errors.New("coinbase: exchange rates without base currency")
+	}
+
+	base := strings.ToUpper(exchangeRates.Currency)
+
+	converter := &Converter{
+		base:      base,
+		rates:     map[string]*big.Rat{base: big.NewRat(1, 1)},
+		invalid:   map[string]string{},
+		timestamp: timestamp,
+	}
+
+	for currency, rate := range exchangeRates.Rates {
+		currency = strings.ToUpper(currency)
+		if currency == base {
+			continue
+		}
+
+		r, err := ParseDecimal(rate)
+		if err != nil || r.Sign() <= 0 {
+			converter.invalid[currency] = rate
+			continue
+		}
+
+		converter.rates[currency] = r.Rat()
+	}
+
+	return converter, nil
+}
+
+// NewConverter fetches the current exchange rates of base and returns a Converter using them.
+func (c *Client) NewConverter(ctx context.Context, base string) (*Converter, error) {
+	exchangeRates, err := c.ListExchangeRates(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConverter(exchangeRates, c.localClock().Now())
+}
+
+// Base returns the base currency of the exchange rates.
+func (cv *Converter) Base() string {
+	return cv.base
+}
+
+// Timestamp returns when the exchange rates were taken.
+func (cv *Converter) Timestamp() time.Time {
+	return cv.timestamp
+}
+
+// Rate returns how many units of to are worth one unit of from.
+func (cv *Converter) Rate(from string, to string) (*big.Rat, error) {
+	fromRate, err := cv.rate(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRate, err := cv.rate(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
+
+// rate returns the exchange rate of currency against the base currency
+func (cv *Converter) rate(currency string) (*big.Rat, error) {
+	currency = strings.ToUpper(currency)
+
+	if r, ok := cv.rates[currency]; ok {
+		return r, nil
+	}
+
+	if rate, ok := cv.invalid[currency]; ok {
+		return nil, fmt.Errorf("%w: %s, invalid rate %q", ErrUnknownCurrency, currency, rate)
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+}
+
+// Convert converts amount from a currency to another.
+func (cv *Converter) Convert(amount *big.Rat, from string, to string) (*big.Rat, error) {
+	rate, err := cv.Rate(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Rat).Mul(amount, rate), nil
+}
+
+// ConvertString converts a decimal amount such as "0.015" from a currency to another,
+// the result is rounded to decimals digits. amount is parsed as ParseDecimal does.
+func (cv *Converter) ConvertString(amount string, from string, to string, decimals int) (string, error) {
+	a, err := ParseDecimal(amount)
+	if err != nil {
+		return "", fmt.Errorf("coinbase: invalid amount %q", amount)
+	}
+
+	converted, err := cv.Convert(a.Rat(), from, to)
+	if err != nil {
+		return "", err
+	}
+
+	return converted.FloatString(decimals), nil
+}
+
+// ConvertMoney converts m to currency to, the result is rounded to decimals digits.
+func (cv *Converter) ConvertMoney(m Money, to string, decimals int) (Money, error) {
+	converted, err := cv.Convert(m.Amount.Rat(), m.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: DecimalFromRat(converted, decimals), Currency: strings.ToUpper(to)}, nil
+}
@@ -0,0 +1,76 @@
+package coinbase
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestConverter(t *testing.T) *Converter {
+	converter, err := NewConverter(&ExchangeRates{Currency: "usd", Rates: map[string]string{
+		"BTC":  "0.00002",
+		"eur":  "0.5",
+		"USD":  "1",
+		"ZERO": "0",
+		"NEG":  "-1",
+		"FRAC": "1/3",
+		"HUGE": "1e999999999",
+	}}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return converter
+}
+
+func TestConvertString(t *testing.T) {
+	converter := newTestConverter(t)
+
+	tests := []struct {
+		amount, from, to string
+		decimals         int
+		out              string
+	}{
+		{"1", "BTC", "USD", 2, "50000.00"},
+		{"0.015", "btc", "eur", 2, "375.00"},
+		{"10", "EUR", "BTC", 8, "0.00040000"},
+		{"-2.5", "USD", "EUR", 2, "-1.25"},
+		{"1e2", "USD", "EUR", 0, "50"},
+		{"1", "USD", "USD", 2, "1.00"},
+	}
+
+	for _, test := range tests {
+		out, err := converter.ConvertString(test.amount, test.from, test.to, test.decimals)
+		if err != nil || out != test.out {
+			t.Errorf("%s %s to %s = %s, %v, want %s", test.amount, test.from, test.to, out, err, test.out)
+		}
+	}
+
+	for _, amount := range []string{"1/3", "1e999999999", "abc", ""} {
+		if out, err := converter.ConvertString(amount, "USD", "EUR", 2); err == nil {
+			t.Errorf("%q converted to %s", amount, out)
+		}
+	}
+}
+
+func TestConverterInvalidRates(t *testing.T) {
+	converter := newTestConverter(t)
+
+	for _, currency := range []string{"ZERO", "NEG", "FRAC", "HUGE", "DOGE"} {
+		if _, err := converter.ConvertString("1", currency, "USD", 2); !errors.Is(err, ErrUnknownCurrency) {
+			t.Errorf("from %s: %v, want %v", currency, err, ErrUnknownCurrency)
+		}
+		if _, err := converter.ConvertString("1", "USD", currency, 2); !errors.Is(err, ErrUnknownCurrency) {
+			t.Errorf("to %s: %v, want %v", currency, err, ErrUnknownCurrency)
+		}
+	}
+
+	money, err := converter.ConvertMoney(Money{Amount: MustParseDecimal("0.001"), Currency: "BTC"}, "eur", 2)
+	if err != nil || money.String() != "25.00 EUR" {
+		t.Fatalf("ConvertMoney = %s, %v", money, err)
+	}
+
+	if _, err = NewConverter(&ExchangeRates{Rates: map[string]string{"BTC": "1"}}, time.Time{}); err == nil {
+		t.Fatalf("exchange rates without base currency accepted")
+	}
+}
@@ -0,0 +1,309 @@
+package coinbase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrDivisionByZero is returned when dividing a Decimal by zero
+var ErrDivisionByZero = errors.New("coinbase: division by zero")
+
+// maxDecimalExponent bounds the exponent accepted by ParseDecimal, larger ones would build huge numbers
+const maxDecimalExponent = 1000
+
+// Decimal is an immutable arbitrary-precision decimal number, the value is unscaled * 10^-scale.
+// A nil *Decimal is treated as zero. It is encoded in JSON as a string, as the API does.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// ParseDecimal parses a decimal number such as "-12.3400" or "1e-8", trailing zeros are preserved.
+// Exponents are limited to ±1000.
+func ParseDecimal(s string) (*Decimal, error) {
+	str := strings.TrimSpace(s)
+
+	exponent := 0
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.Atoi(str[i+1:])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return nil, fmt.Errorf("coinbase: invalid decimal %q", s)
+		}
+		exponent = e
+		str = str[:i]
+	}
+
+	scale := 0
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		scale = len(str) - i - 1
+		str = str[:i] + str[i+1:]
+	}
+
+	digits := strings.TrimLeft(str, "+-")
+	if digits == "" || strings.Trim(digits, "0123456789") != "" || len(str)-len(digits) > 1 {
+		return nil, fmt.Errorf("coinbase: invalid decimal %q", s)
+	}
+
+	unscaled, _ := new(big.Int).SetString(str, 10)
+
+	d := &Decimal{unscaled: unscaled, scale: scale - exponent}
+	if d.scale < 0 {
+		return d.rescale(0), nil
+	}
+
+	return d, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s is not a valid decimal.
+func MustParseDecimal(s string) *Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// NewDecimal returns unscaled * 10^-scale, e.g. NewDecimal(150, 2) is 1.50
+func NewDecimal(unscaled int64, scale int) *Decimal {
+	d := &Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+	if scale < 0 {
+		return d.rescale(0)
+	}
+
+	return d
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d *Decimal) Scale() int {
+	if d == nil {
+		return 0
+	}
+
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d *Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d is zero.
+func (d *Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares d and o, returning -1, 0 or +1.
+func (d *Decimal) Cmp(o *Decimal) int {
+	a, b := align(d, o)
+	return a.Cmp(b)
+}
+
+// Add returns d + o.
+func (d *Decimal) Add(o *Decimal) *Decimal {
+	a, b := align(d, o)
+	return &Decimal{unscaled: new(big.Int).Add(a, b), scale: maxScale(d, o)}
+}
+
+// Sub returns d - o.
+func (d *Decimal) Sub(o *Decimal) *Decimal {
+	a, b := align(d, o)
+	return &Decimal{unscaled: new(big.Int).Sub(a, b), scale: maxScale(d, o)}
+}
+
+// Mul returns d * o.
+func (d *Decimal) Mul(o *Decimal) *Decimal {
+	return &Decimal{unscaled: new(big.Int).Mul(d.int(), o.int()), scale: d.Scale() + o.Scale()}
+}
+
+// Quo returns d / o rounded to places digits after the decimal point.
+func (d *Decimal) Quo(o *Decimal, places int) (*Decimal, error) {
+	if o.IsZero() {
+		return nil, ErrDivisionByZero
+	}
+
+	return DecimalFromRat(new(big.Rat).Quo(d.Rat(), o.Rat()), places), nil
+}
+
+// Neg returns -d.
+func (d *Decimal) Neg() *Decimal {
+	return &Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.Scale()}
+}
+
+// Abs returns |d|.
+func (d *Decimal) Abs() *Decimal {
+	return &Decimal{unscaled: new(big.Int).Abs(d.int()), scale: d.Scale()}
+}
+
+// Round returns d rounded half away from zero to places digits after the decimal point.
+func (d *Decimal) Round(places int) *Decimal {
+	if places < 0 {
+		places = 0
+	}
+
+	if d.Scale() <= places {
+		return d.rescale(places)
+	}
+
+	divisor := pow10(d.Scale() - places)
+
+	quo, rem := new(big.Int).QuoRem(new(big.Int).Abs(d.int()), divisor, new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(divisor) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+
+	if d.Sign() < 0 {
+		quo.Neg(quo)
+	}
+
+	return &Decimal{unscaled: quo, scale: places}
+}
+
+// Truncate returns d truncated to places digits after the decimal point.
+func (d *Decimal) Truncate(places int) *Decimal {
+	if places < 0 {
+		places = 0
+	}
+
+	if d.Scale() <= places {
+		return d.rescale(places)
+	}
+
+	return &Decimal{unscaled: new(big.Int).Quo(d.int(), pow10(d.Scale()-places)), scale: places}
+}
+
+// Rat returns d as an exact rational number.
+func (d *Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.int(), pow10(d.Scale()))
+}
+
+// DecimalFromRat returns r rounded half away from zero to places digits after the decimal point.
+func DecimalFromRat(r *big.Rat, places int) *Decimal {
+	if places < 0 {
+		places = 0
+	}
+
+	// FloatString rounds half away from zero
+	d, _ := ParseDecimal(r.FloatString(places))
+
+	return d
+}
+
+// String returns d in plain decimal notation, e.g. -0.0100
+func (d *Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+
+	scale := d.Scale()
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// MarshalJSON encodes d as a JSON string
+func (d *Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes d from a JSON string or number
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return err
+		}
+		s = unquoted
+	}
+
+	if s == "" {
+		*d = Decimal{}
+		return nil
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+
+	*d = *parsed
+
+	return nil
+}
+
+// int returns the unscaled value, zero for nil
+func (d *Decimal) int() *big.Int {
+	if d == nil || d.unscaled == nil {
+		return new(big.Int)
+	}
+
+	return d.unscaled
+}
+
+// rescale returns d with scale digits after the decimal point, scale must not be lower than the current one
+func (d *Decimal) rescale(scale int) *Decimal {
+	unscaled := new(big.Int).Mul(d.int(), pow10(scale-d.Scale()))
+	if scale < d.Scale() {
+		unscaled = new(big.Int).Quo(d.int(), pow10(d.Scale()-scale))
+	}
+
+	return &Decimal{unscaled: unscaled, scale: scale}
+}
+
+// trim returns d without trailing zeros after the decimal point
+func (d *Decimal) trim() *Decimal {
+	unscaled, scale := new(big.Int).Set(d.int()), d.Scale()
+
+	ten, remainder := big.NewInt(10), new(big.Int)
+	for scale > 0 {
+		quotient, r := new(big.Int).QuoRem(unscaled, ten, remainder)
+		if r.Sign() != 0 {
+			break
+		}
+		unscaled, scale = quotient, scale-1
+	}
+
+	return &Decimal{unscaled: unscaled, scale: scale}
+}
+
+// align returns the unscaled values of a and b brought to the same scale
+func align(a *Decimal, b *Decimal) (*big.Int, *big.Int) {
+	scale := maxScale(a, b)
+	return a.rescale(scale).int(), b.rescale(scale).int()
+}
+
+// maxScale returns the highest scale of a and b
+func maxScale(a *Decimal, b *Decimal) int {
+	if a.Scale() > b.Scale() {
+		return a.Scale()
+	}
+
+	return b.Scale()
+}
+
+// pow10 returns 10^n, n must not be negative
+func pow10(n int) *big.Int {
+	if n <= 0 {
+		return big.NewInt(1)
+	}
+
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,185 @@
+package coinbase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string // empty when in is invalid
+	}{
+		{"0", "0"},
+		{"12.34", "12.34"},
+		{"-12.3400", "-12.3400"},
+		{"+1.5", "1.5"},
+		{" 0.0100 ", "0.0100"},
+		{".5", "0.5"},
+		{"-0.00000001", "-0.00000001"},
+		{"1e-8", "0.00000001"},
+		{"1.5E2", "150"},
+		{"-2.50e1", "-25.0"},
+		{"1e1000", "1" + zeros(1000)},
+		{"1e-1000", "0." + zeros(999) + "1"},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+		{"", ""},
+		{"-", ""},
+		{"--1", ""},
+		{"1-", ""},
+		{"1.2.3", ""},
+		{"1/3", ""},
+		{"0x10", ""},
+		{"1e", ""},
+		{"1e1001", ""},
+		{"1e-1001", ""},
+		{"1e999999999999", ""},
+		{"NaN", ""},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.in)
+		if test.out == "" {
+			if err == nil {
+				t.Errorf("ParseDecimal(%q) = %s, want an error", test.in, d)
+			}
+			continue
+		}
+		if err != nil || d.String() != test.out {
+			t.Errorf("ParseDecimal(%q) = %s, %v, want %s", test.in, d, err, test.out)
+		}
+	}
+}
+
+func zeros(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = '0'
+	}
+
+	return string(b)
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		d   *Decimal
+		out string
+	}{
+		{nil, "0"},
+		{NewDecimal(150, 2), "1.50"},
+		{NewDecimal(-1, 2), "-0.01"},
+		{NewDecimal(5, 0), "5"},
+		{NewDecimal(5, -2), "500"},
+		{NewDecimal(0, 3), "0.000"},
+	}
+
+	for _, test := range tests {
+		if s := test.d.String(); s != test.out {
+			t.Errorf("String() = %s, want %s", s, test.out)
+		}
+	}
+}
+
+func TestDecimalRoundAndTruncate(t *testing.T) {
+	tests := []struct {
+		in       string
+		places   int
+		round    string
+		truncate string
+	}{
+		{"1.005", 2, "1.01", "1.00"},
+		{"1.004", 2, "1.00", "1.00"},
+		{"-1.005", 2, "-1.01", "-1.00"},
+		{"-1.0049", 2, "-1.00", "-1.00"},
+		{"2.5", 0, "3", "2"},
+		{"-2.5", 0, "-3", "-2"},
+		{"0.99", 1, "1.0", "0.9"},
+		{"1.2", 4, "1.2000", "1.2000"},
+		{"1.25", -1, "1", "1"},
+	}
+
+	for _, test := range tests {
+		d := MustParseDecimal(test.in)
+		if r := d.Round(test.places).String(); r != test.round {
+			t.Errorf("%s.Round(%d) = %s, want %s", test.in, test.places, r, test.round)
+		}
+		if r := d.Truncate(test.places).String(); r != test.truncate {
+			t.Errorf("%s.Truncate(%d) = %s, want %s", test.in, test.places, r, test.truncate)
+		}
+	}
+}
+
+func TestDecimalQuo(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		places int
+		out    string
+	}{
+		{"1", "3", 4, "0.3333"},
+		{"2", "3", 4, "0.6667"},
+		{"-2", "3", 2, "-0.67"},
+		{"10", "4", 0, "3"},
+		{"0.5", "0.00002", 8, "25000.00000000"},
+	}
+
+	for _, test := range tests {
+		q, err := MustParseDecimal(test.a).Quo(MustParseDecimal(test.b), test.places)
+		if err != nil || q.String() != test.out {
+			t.Errorf("%s / %s = %s, %v, want %s", test.a, test.b, q, err, test.out)
+		}
+	}
+
+	if _, err := MustParseDecimal("1").Quo(MustParseDecimal("0.00"), 2); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("division by zero: %v, want %v", err, ErrDivisionByZero)
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		cmp  int
+	}{
+		{"1", "1.000", 0},
+		{"0.1", "0.10", 0},
+		{"-0.1", "0.1", -1},
+		{"1e2", "99.99", 1},
+		{"-1", "-2", 1},
+		{"0", "-0.00", 0},
+	}
+
+	for _, test := range tests {
+		if cmp := MustParseDecimal(test.a).Cmp(MustParseDecimal(test.b)); cmp != test.cmp {
+			t.Errorf("%s cmp %s = %d, want %d", test.a, test.b, cmp, test.cmp)
+		}
+	}
+
+	var zero *Decimal
+	if zero.Cmp(MustParseDecimal("0.0")) != 0 || zero.Cmp(MustParseDecimal("0.1")) != -1 {
+		t.Errorf("nil is not compared as zero")
+	}
+}
+
+func TestCurrencyPrecision(t *testing.T) {
+	tests := []struct {
+		minSize   string
+		precision int
+	}{
+		{"0.01", 2},
+		{"0.01000000", 2},
+		{"0.00000001", 8},
+		{"1", 0},
+		{"1.0", 0},
+		{"1e-8", 8},
+	}
+
+	for _, test := range tests {
+		precision, err := Currency{MinSize: test.minSize}.Precision()
+		if err != nil || precision != test.precision {
+			t.Errorf("precision of %s = %d, %v, want %d", test.minSize, precision, err, test.precision)
+		}
+	}
+
+	if _, err := (Currency{MinSize: "1e5000"}).Precision(); err == nil {
+		t.Errorf("oversized exponent accepted as MinSize")
+	}
+}
@@ -2,17 +2,18 @@ package coinbase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
 // ListDeposits Lists deposits for an account.
 // Endpoint: GET /accounts/:account_id/deposits
-func (c *Client) ListDeposits(ctx context.Context, accountID string) (*[]Deposit, *Pagination, error) {
+func (c *Client) ListDeposits(ctx context.Context, accountID string, opts *ListOptions) (*[]Deposit, *Pagination, error) {
 	deposits := &[]Deposit{}
 
 	pagination := &Pagination{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "deposits"), nil)
+	req, err := c.NewRequest(ctx, "GET", withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "deposits"), opts), nil)
 	if err != nil {
 		return deposits, pagination, err
 	}
@@ -24,12 +25,35 @@ func (c *Client) ListDeposits(ctx context.Context, accountID string) (*[]Deposit
 	return deposits, pagination, nil
 }
 
+// IterateDeposits Returns an Iterator over all deposits for an account, following pagination automatically.
+// Every Value is a Deposit.
+func (c *Client) IterateDeposits(ctx context.Context, accountID string, opts *ListOptions) *Iterator {
+	return c.newIterator(ctx, withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "deposits"), opts), func(data []byte) (interface{}, error) {
+		deposit := Deposit{}
+		err := json.Unmarshal(data, &deposit)
+		return deposit, err
+	})
+}
+
+// ListAllDeposits Lists all deposits for an account, fetching every page.
+func (c *Client) ListAllDeposits(ctx context.Context, accountID string, opts *ListOptions) (*[]Deposit, error) {
+	deposits := &[]Deposit{}
+
+	it := c.IterateDeposits(ctx, accountID, opts)
+	for it.Next() {
+		*deposits = append(*deposits, it.Value().(Deposit))
+	}
+
+	return deposits, it.Err()
+}
+
 // GetDeposit Show an individual deposit.
+// Related resources listed in expand, e.g. ExpandAll, are embedded in the response.
 // Endpoint: GET /accounts/:account_id/deposits/:deposit_id
-func (c *Client) GetDeposit(ctx context.Context, accountID string, depositID string) (*Deposit, error) {
+func (c *Client) GetDeposit(ctx context.Context, accountID string, depositID string, expand ...string) (*Deposit, error) {
 	deposit := &Deposit{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "deposits", depositID), nil)
+	req, err := c.NewRequest(ctx, "GET", withExpand(fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "deposits", depositID), expand), nil)
 	if err != nil {
 		return deposit, err
 	}
@@ -51,7 +75,8 @@ func (c *Client) DepositFunds(ctx context.Context, accountID string, depositData
 		return deposit, err
 	}
 
-	if err = c.SendWithAuth(req, deposit); err != nil {
+	action := PolicyAction{Operation: TransactionTypeFiatDeposit, AccountID: accountID, Amount: Money{Amount: depositData.Amount, Currency: depositData.Currency}}
+	if err = c.guard(ctx, action, func() error { return c.SendWithAuth(req, deposit) }); err != nil {
 		return deposit, err
 	}
 
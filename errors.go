@@ -0,0 +1,125 @@
+package coinbase
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Errors documented by Coinbase, an ErrorResponse matches them through errors.Is
+// https://developers.coinbase.com/api/v2#error-response
+var (
+	ErrNotFound                = errors.New("coinbase: not found")
+	ErrValidation              = errors.New("coinbase: validation error")
+	ErrInvalidRequest          = errors.New("coinbase: invalid request")
+	ErrTwoFactorRequired       = errors.New("coinbase: two factor required")
+	ErrAuthentication          = errors.New("coinbase: authentication error")
+	ErrInvalidToken            = errors.New("coinbase: invalid token")
+	ErrRevokedToken            = errors.New("coinbase: revoked token")
+	ErrExpiredToken            = errors.New("coinbase: expired token")
+	ErrRateLimitExceeded       = errors.New("coinbase: rate limit exceeded")
+	ErrInternalServer          = errors.New("coinbase: internal server error")
+	ErrPersonalDetailsRequired = errors.New("coinbase: personal details required")
+	ErrUnverifiedEmail         = errors.New("coinbase: unverified email")
+)
+
+// Error categories, an ErrorResponse matches them through errors.Is
+var (
+	ErrRetryLater         = errors.New("coinbase: retry later")          // Rate limits and server errors
+	ErrBadCredentials     = errors.New("coinbase: bad credentials")      // Authentication errors and invalid, revoked or expired tokens
+	ErrUserActionRequired = errors.New("coinbase: user action required") // Two factor, personal details or email verification required
+)
+
+// errorIDs maps Coinbase error IDs to their error
+var errorIDs = map[string]error{
+	"not_found":                 ErrNotFound,
+	"validation_error":          ErrValidation,
+	"invalid_request":           ErrInvalidRequest,
+	"two_factor_required":       ErrTwoFactorRequired,
+	"authentication_error":      ErrAuthentication,
+	"invalid_token":             ErrInvalidToken,
+	"revoked_token":             ErrRevokedToken,
+	"expired_token":             ErrExpiredToken,
+	"rate_limit_exceeded":       ErrRateLimitExceeded,
+	"internal_server_error":     ErrInternalServer,
+	"personal_details_required": ErrPersonalDetailsRequired,
+	"unverified_email":          ErrUnverifiedEmail,
+}
+
+// statusErrors maps HTTP status codes to their error, used when the response carries no error ID
+var statusErrors = map[int]error{
+	http.StatusBadRequest:          ErrInvalidRequest,
+	http.StatusUnauthorized:        ErrAuthentication,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusUnprocessableEntity: ErrValidation,
+	http.StatusTooManyRequests:     ErrRateLimitExceeded,
+	http.StatusInternalServerError: ErrInternalServer,
+}
+
+// errorCategories maps every category to the errors belonging to it
+var errorCategories = map[error][]error{
+	ErrRetryLater:         {ErrRateLimitExceeded, ErrInternalServer},
+	ErrBadCredentials:     {ErrAuthentication, ErrInvalidToken, ErrRevokedToken, ErrExpiredToken},
+	ErrUserActionRequired: {ErrTwoFactorRequired, ErrPersonalDetailsRequired, ErrUnverifiedEmail},
+}
+
+// Is reports whether the response carries the error target, either one of the documented errors or a category.
+func (r *ErrorResponse) Is(target error) bool {
+	for _, err := range r.errs() {
+		if err == target {
+			return true
+		}
+
+		for _, member := range errorCategories[target] {
+			if err == member {
+				return true
+			}
+		}
+	}
+
+	if target == ErrRetryLater && r.StatusCode >= 500 {
+		return true
+	}
+
+	return false
+}
+
+// HasID reports whether the response carries an error with the given Coinbase ID.
+func (r *ErrorResponse) HasID(id string) bool {
+	for _, e := range r.Errors {
+		if e.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// errs returns the documented errors carried by the response
+func (r *ErrorResponse) errs() []error {
+	errs := []error{}
+
+	for _, e := range r.Errors {
+		if err, ok := errorIDs[e.ID]; ok {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		if err, ok := statusErrors[r.StatusCode]; ok {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// requestID extracts the request identifier assigned by Coinbase
+func requestID(resp *http.Response) string {
+	for _, header := range []string{"CB-Request-Id", "X-Request-Id", "CB-Trace-Id"} {
+		if id := resp.Header.Get(header); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
@@ -3,14 +3,20 @@ package coinbase
 import (
 	"context"
 	"fmt"
+	"net/url"
 )
 
-// ListExchangeRates Get current exchange rates.
+// ListExchangeRates Get current exchange rates, currency defaults to USD when empty.
 // Endpoint: GET /exchange-rates
 func (c *Client) ListExchangeRates(ctx context.Context, currency string) (*ExchangeRates, error) {
 	exchangeRates := &ExchangeRates{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s", c.APIBase, "exchange-rates"), currency)
+	uri := fmt.Sprintf("%s/%s", c.APIBase, "exchange-rates")
+	if currency != "" {
+		uri += "?" + url.Values{"currency": {currency}}.Encode()
+	}
+
+	req, err := c.NewRequest(ctx, "GET", uri, nil)
 	if err != nil {
 		return exchangeRates, err
 	}
@@ -0,0 +1,126 @@
+package coinbase
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// ExpandAll expands every related resource
+const ExpandAll = "all"
+
+// refFields are the fields of a reference, objects carrying more fields are expanded resources
+var refFields = map[string]bool{"id": true, "resource": true, "resource_path": true}
+
+// withExpand appends an expand[] query parameter for every resource in expand
+func withExpand(uri string, expand []string) string {
+	if len(expand) == 0 {
+		return uri
+	}
+
+	values := url.Values{"expand[]": expand}
+
+	if strings.Contains(uri, "?") {
+		return uri + "&" + values.Encode()
+	}
+
+	return uri + "?" + values.Encode()
+}
+
+// decodeRef decodes the reference fields of data into ref and, when data is an expanded resource, the whole object into expanded.
+// It reports whether the resource was expanded.
+func decodeRef(data []byte, ref *ResourceRef, expanded interface{}) (bool, error) {
+	if err := json.Unmarshal(data, ref); err != nil {
+		return false, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false, err
+	}
+
+	for field := range fields {
+		if !refFields[field] {
+			return true, json.Unmarshal(data, expanded)
+		}
+	}
+
+	return false, nil
+}
+
+// UnmarshalJSON decodes a buy reference or an expanded buy
+func (r *BuyRef) UnmarshalJSON(data []byte) error {
+	buy := &Buy{}
+
+	expanded, err := decodeRef(data, &r.ResourceRef, buy)
+	if expanded {
+		r.Expanded = buy
+	}
+
+	return err
+}
+
+// UnmarshalJSON decodes a payment method reference or an expanded payment method
+func (r *PaymentMethodRef) UnmarshalJSON(data []byte) error {
+	paymentMethod := &PaymentMethod{}
+
+	expanded, err := decodeRef(data, &r.ResourceRef, paymentMethod)
+	if expanded {
+		r.Expanded = paymentMethod
+	}
+
+	return err
+}
+
+// UnmarshalJSON decodes a sell reference or an expanded sell
+func (r *SellRef) UnmarshalJSON(data []byte) error {
+	sell := &Sell{}
+
+	expanded, err := decodeRef(data, &r.ResourceRef, sell)
+	if expanded {
+		r.Expanded = sell
+	}
+
+	return err
+}
+
+// UnmarshalJSON decodes a transaction reference or an expanded transaction
+func (r *TransactionRef) UnmarshalJSON(data []byte) error {
+	transaction := &Transaction{}
+
+	expanded, err := decodeRef(data, &r.ResourceRef, transaction)
+	if expanded {
+		r.Expanded = transaction
+	}
+
+	return err
+}
+
+// UnmarshalJSON decodes a transaction party, expanded users and accounts are decoded into User and Account
+func (p *TransactionParty) UnmarshalJSON(data []byte) error {
+	// party has the same fields without the UnmarshalJSON method, avoiding recursion
+	type party TransactionParty
+
+	decoded := party{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = TransactionParty(decoded)
+
+	var err error
+
+	switch p.Kind() {
+	case PartyUser:
+		user := &User{}
+		if expanded, e := decodeRef(data, &ResourceRef{}, user); expanded {
+			p.User, err = user, e
+		}
+	case PartyAccount:
+		account := &Account{}
+		if expanded, e := decodeRef(data, &ResourceRef{}, account); expanded {
+			p.Account, err = account, e
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,188 @@
+package coinbase
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// jwtLifetime is the validity of every request token, Coinbase rejects tokens valid for longer than 2 minutes
+const jwtLifetime = 2 * time.Minute
+
+// ErrInvalidPrivateKey is returned when a CDP API key secret cannot be parsed
+var ErrInvalidPrivateKey = errors.New("coinbase: invalid CDP private key, expected an EC (ES256) PEM key or a base64 Ed25519 key")
+
+// JWTAuth authenticates requests with Coinbase Developer Platform API keys,
+// signing every request with a short-lived ES256 or Ed25519 JWT.
+// https://docs.cdp.coinbase.com/coinbase-app/docs/api-key-authentication
+type JWTAuth struct {
+	keyName string
+	key     crypto.Signer
+	alg     string
+}
+
+// NewJWTAuth returns a JWTAuth for the CDP API key named keyName, e.g. organizations/{org_id}/apiKeys/{key_id}.
+// privateKey is either an EC private key in PEM format (SEC1 or PKCS8) or a base64 encoded Ed25519 key.
+func NewJWTAuth(keyName string, privateKey string) (*JWTAuth, error) {
+	key, alg, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTAuth{
+		keyName: keyName,
+		key:     key,
+		alg:     alg,
+	}, nil
+}
+
+// NewCDPClient returns a Client authenticating with a Coinbase Developer Platform API key
+func NewCDPClient(keyName string, privateKey string) (*Client, error) {
+	auth, err := NewJWTAuth(keyName, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClientWithAuthenticator(auth), nil
+}
+
+// Authenticate signs a JWT for the request and applies it as bearer token
+func (a *JWTAuth) Authenticate(req *http.Request, now time.Time) error {
+	token, err := a.generateJWT(req.Method, req.URL.Host, req.URL.Path, now)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return nil
+}
+
+// generateJWT builds the token, the uri claim binds it to a single method, host and path
+func (a *JWTAuth) generateJWT(method, host, path string, now time.Time) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	header := map[string]interface{}{
+		"alg":   a.alg,
+		"kid":   a.keyName,
+		"nonce": hex.EncodeToString(nonce),
+		"typ":   "JWT",
+	}
+
+	claims := map[string]interface{}{
+		"sub": a.keyName,
+		"iss": "cdp",
+		"nbf": now.Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"uri": fmt.Sprintf("%s %s%s", method, host, path),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	signature, err := a.sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// sign computes the JWS signature of input
+func (a *JWTAuth) sign(input []byte) ([]byte, error) {
+	switch key := a.key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, input), nil
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(input)
+
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			return nil, err
+		}
+
+		// ES256 signatures are the concatenation of r and s, each padded to 32 bytes
+		signature := make([]byte, 64)
+		rBytes, sBytes := r.Bytes(), s.Bytes()
+		copy(signature[32-len(rBytes):32], rBytes)
+		copy(signature[64-len(sBytes):], sBytes)
+
+		return signature, nil
+	}
+
+	return nil, ErrInvalidPrivateKey
+}
+
+// parsePrivateKey loads a CDP private key and returns the matching JWS algorithm
+func parsePrivateKey(privateKey string) (crypto.Signer, string, error) {
+	// Keys copied from JSON files usually carry escaped newlines
+	privateKey = strings.TrimSpace(strings.Replace(privateKey, `\n`, "\n", -1))
+
+	if block, _ := pem.Decode([]byte(privateKey)); block != nil {
+		if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+			return es256Key(key)
+		}
+
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, "", ErrInvalidPrivateKey
+		}
+
+		switch key := key.(type) {
+		case *ecdsa.PrivateKey:
+			return es256Key(key)
+		case ed25519.PrivateKey:
+			return key, "EdDSA", nil
+		}
+
+		return nil, "", ErrInvalidPrivateKey
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, "", ErrInvalidPrivateKey
+	}
+
+	switch len(raw) {
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), "EdDSA", nil
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), "EdDSA", nil
+	}
+
+	return nil, "", ErrInvalidPrivateKey
+}
+
+// es256Key checks that key can be used with ES256, which requires the P-256 curve
+func es256Key(key *ecdsa.PrivateKey) (crypto.Signer, string, error) {
+	if key.Curve != elliptic.P256() {
+		return nil, "", ErrInvalidPrivateKey
+	}
+
+	return key, "ES256", nil
+}
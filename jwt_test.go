@@ -0,0 +1,203 @@
+package coinbase
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKeyName = "organizations/org/apiKeys/key"
+
+// verifyJWT checks the header, claims and signature of token against the public key of a CDP key
+func verifyJWT(t *testing.T, token string, public crypto.PublicKey, alg string, uri string, now time.Time) {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token has %d parts", len(parts))
+	}
+
+	header := map[string]interface{}{}
+	claims := map[string]interface{}{}
+	for i, v := range []interface{}{&header, &claims} {
+		data, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			t.Fatalf("part %d: %v", i, err)
+		}
+		if err = json.Unmarshal(data, v); err != nil {
+			t.Fatalf("part %d: %v", i, err)
+		}
+	}
+
+	if header["alg"] != alg || header["kid"] != testKeyName || header["typ"] != "JWT" || len(fmt.Sprint(header["nonce"])) != 32 {
+		t.Errorf("header = %v", header)
+	}
+
+	if claims["sub"] != testKeyName || claims["iss"] != "cdp" || claims["uri"] != uri {
+		t.Errorf("claims = %v, want uri %q", claims, uri)
+	}
+	if claims["nbf"] != float64(now.Unix()) || claims["exp"] != float64(now.Add(2*time.Minute).Unix()) {
+		t.Errorf("nbf %v, exp %v, want %d and %d", claims["nbf"], claims["exp"], now.Unix(), now.Add(2*time.Minute).Unix())
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := []byte(parts[0] + "." + parts[1])
+
+	switch key := public.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(input)
+		if len(signature) != 64 || !ecdsa.Verify(key, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])) {
+			t.Errorf("invalid ES256 signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, input, signature) {
+			t.Errorf("invalid EdDSA signature")
+		}
+	default:
+		t.Fatalf("unexpected key %T", public)
+	}
+}
+
+// testKeys returns CDP secrets in every accepted format, with their public key and algorithm
+func testKeys(t *testing.T) []struct {
+	name   string
+	secret string
+	public crypto.PublicKey
+	alg    string
+} {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPKCS8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPKCS8, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sec1PEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}))
+
+	return []struct {
+		name   string
+		secret string
+		public crypto.PublicKey
+		alg    string
+	}{
+		{"SEC1", sec1PEM, &ecKey.PublicKey, "ES256"},
+		{"SEC1 with escaped newlines", strings.Replace(sec1PEM, "\n", `\n`, -1), &ecKey.PublicKey, "ES256"},
+		{"EC PKCS8", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPKCS8})), &ecKey.PublicKey, "ES256"},
+		{"Ed25519 PKCS8", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edPKCS8})), edPublic, "EdDSA"},
+		{"Ed25519 base64", base64.StdEncoding.EncodeToString(edKey), edPublic, "EdDSA"},
+		{"Ed25519 base64 seed", base64.StdEncoding.EncodeToString(edKey.Seed()), edPublic, "EdDSA"},
+	}
+}
+
+func TestJWTAuth(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	for _, key := range testKeys(t) {
+		auth, err := NewJWTAuth(testKeyName, key.secret)
+		if err != nil {
+			t.Errorf("%s: %v", key.name, err)
+			continue
+		}
+
+		req := httptest.NewRequest("POST", "https://api.coinbase.com/v2/accounts/a1/transactions?limit=10", nil)
+		if err = auth.Authenticate(req, now); err != nil {
+			t.Fatalf("%s: %v", key.name, err)
+		}
+
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		verifyJWT(t, token, key.public, key.alg, "POST api.coinbase.com/v2/accounts/a1/transactions", now)
+	}
+}
+
+func TestJWTAuthInvalidKeys(t *testing.T) {
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(p384)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{
+		"",
+		"not a key",
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("garbage")})),
+		base64.StdEncoding.EncodeToString(make([]byte, 48)),
+	} {
+		if _, err := NewJWTAuth(testKeyName, secret); !errors.Is(err, ErrInvalidPrivateKey) {
+			t.Errorf("%.40q: %v, want %v", secret, err, ErrInvalidPrivateKey)
+		}
+	}
+
+	if _, err := NewCDPClient(testKeyName, "not a key"); !errors.Is(err, ErrInvalidPrivateKey) {
+		t.Errorf("NewCDPClient: %v, want %v", err, ErrInvalidPrivateKey)
+	}
+}
+
+func TestCDPClient(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC))
+
+	for _, key := range testKeys(t)[2:4] {
+		var server *httptest.Server
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("CB-ACCESS-KEY") != "" || r.Header.Get("CB-ACCESS-SIGN") != "" {
+				t.Errorf("%s: API key headers sent", key.name)
+			}
+
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			verifyJWT(t, token, key.public, key.alg, "GET "+server.Listener.Addr().String()+"/v2/user", clock.Now())
+
+			fmt.Fprint(w, `{"data":{"id":"u1"}}`)
+		}))
+
+		c, err := NewCDPClient(testKeyName, key.secret)
+		if err != nil {
+			t.Fatalf("%s: %v", key.name, err)
+		}
+		c.APIBase = server.URL + "/v2"
+		c.Clock = clock
+
+		if user, err := c.GetUser(context.Background()); err != nil || user.ID != "u1" {
+			t.Errorf("%s: %v, %v", key.name, user, err)
+		}
+
+		server.Close()
+	}
+}
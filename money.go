@@ -0,0 +1,116 @@
+package coinbase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when combining amounts of different currencies
+var ErrCurrencyMismatch = errors.New("coinbase: currency mismatch")
+
+// NewMoney returns the decimal amount of currency, e.g. NewMoney("0.01", "BTC").
+func NewMoney(amount string, currency string) (Money, error) {
+	d, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: d, Currency: strings.ToUpper(currency)}, nil
+}
+
+// Add returns m + o, both must be in the same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount.Add(o.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns m - o, both must be in the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount.Sub(o.Amount), Currency: m.Currency}, nil
+}
+
+// Mul returns m multiplied by factor.
+func (m Money) Mul(factor *Decimal) Money {
+	return Money{Amount: m.Amount.Mul(factor), Currency: m.Currency}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
+
+// Cmp compares m and o, returning -1, 0 or +1. Both must be in the same currency.
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+
+	return m.Amount.Cmp(o.Amount), nil
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// Round returns m rounded half away from zero to places digits after the decimal point.
+func (m Money) Round(places int) Money {
+	return Money{Amount: m.Amount.Round(places), Currency: m.Currency}
+}
+
+// RoundTo returns m rounded to the precision of currency, as given by its MinSize.
+func (m Money) RoundTo(currency Currency) (Money, error) {
+	if !strings.EqualFold(currency.ID, m.Currency) {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, currency.ID)
+	}
+
+	precision, err := currency.Precision()
+	if err != nil {
+		return Money{}, err
+	}
+
+	return m.Round(precision), nil
+}
+
+// String returns the amount followed by the currency code, e.g. 0.01 BTC
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// sameCurrency checks that m and o are in the same currency
+func (m Money) sameCurrency(o Money) error {
+	if !strings.EqualFold(m.Currency, o.Currency) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+
+	return nil
+}
+
+// Money returns the price as Money.
+func (p Price) Money() Money {
+	return Money{Amount: p.Amount, Currency: p.Currency}
+}
+
+// Precision returns the number of digits after the decimal point of the currency, given by MinSize e.g. 2 for 0.01
+func (c Currency) Precision() (int, error) {
+	minSize, err := ParseDecimal(c.MinSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Trailing zeros do not count, e.g. 0.01000000 has precision 2
+	precision := minSize.Scale()
+	for precision > 0 && minSize.Round(precision-1).Cmp(minSize) == 0 {
+		precision--
+	}
+
+	return precision, nil
+}
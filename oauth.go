@@ -0,0 +1,298 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Coinbase Connect OAuth2 endpoints
+const (
+	OAuth2AuthURL   = "https://www.coinbase.com/oauth/authorize"
+	OAuth2TokenURL  = "https://api.coinbase.com/oauth/token"
+	OAuth2RevokeURL = "https://api.coinbase.com/oauth/revoke"
+)
+
+// tokenExpiryMargin is how long before its expiry an access token is refreshed
+const tokenExpiryMargin = time.Minute
+
+// ErrNoRefreshToken is returned when an expired access token cannot be refreshed
+var ErrNoRefreshToken = errors.New("coinbase: access token expired and no refresh token available")
+
+// OAuth2Auth authenticates requests with an OAuth2 bearer token, refreshing it when expired.
+// It is safe for concurrent use.
+type OAuth2Auth struct {
+	// OnTokenRefresh, if set, is called with every new token so that it can be persisted.
+	// Refresh tokens are rotated, the previous one is no longer valid after a refresh unless it is kept by the server.
+	OnTokenRefresh func(token *OAuth2Token) error
+
+	config *OAuth2Config
+	mu     sync.Mutex
+	token  *OAuth2Token
+}
+
+// NewOAuth2Auth returns an OAuth2Auth using token, as returned by OAuth2Config.Exchange or loaded from storage.
+func NewOAuth2Auth(config *OAuth2Config, token *OAuth2Token) *OAuth2Auth {
+	return &OAuth2Auth{
+		config: config,
+		token:  token,
+	}
+}
+
+// Authenticate applies the bearer token to the request, refreshing it first when expired
+func (a *OAuth2Auth) Authenticate(req *http.Request, now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == nil {
+		return ErrNoRefreshToken
+	}
+
+	if a.token.expired(now) {
+		if err := a.refresh(req.Context()); err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.token.AccessToken)
+
+	return nil
+}
+
+// Token returns a copy of the current token.
+func (a *OAuth2Auth) Token() OAuth2Token {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == nil {
+		return OAuth2Token{}
+	}
+
+	return *a.token
+}
+
+// Refresh forces a refresh of the access token.
+func (a *OAuth2Auth) Refresh(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.refresh(ctx)
+}
+
+// Revoke revokes the current access token, it can no longer be used afterwards.
+func (a *OAuth2Auth) Revoke(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == nil {
+		return nil
+	}
+
+	if err := a.config.Revoke(ctx, a.token.AccessToken); err != nil {
+		return err
+	}
+
+	a.token = nil
+
+	return nil
+}
+
+// refresh exchanges the refresh token for a new token, the caller must hold the lock
+func (a *OAuth2Auth) refresh(ctx context.Context) error {
+	if a.token == nil || a.token.RefreshToken == "" {
+		return ErrNoRefreshToken
+	}
+
+	token, err := a.config.Refresh(ctx, a.token.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	if a.OnTokenRefresh != nil {
+		if err := a.OnTokenRefresh(token); err != nil {
+			return err
+		}
+	}
+
+	a.token = token
+
+	return nil
+}
+
+// AuthCodeURL returns the URL of the consent page the user must be redirected to.
+// state protects against CSRF and is returned unchanged to RedirectURL, extra holds
+// additional parameters such as account or meta[send_limit_amount].
+func (c *OAuth2Config) AuthCodeURL(state string, extra url.Values) string {
+	values := url.Values{}
+	for key := range extra {
+		values[key] = extra[key]
+	}
+
+	values.Set("response_type", "code")
+	values.Set("client_id", c.ClientID)
+	if c.RedirectURL != "" {
+		values.Set("redirect_uri", c.RedirectURL)
+	}
+	if state != "" {
+		values.Set("state", state)
+	}
+	if len(c.Scopes) > 0 {
+		values.Set("scope", strings.Join(c.Scopes, ","))
+	}
+
+	authURL := c.AuthURL
+	if authURL == "" {
+		authURL = OAuth2AuthURL
+	}
+
+	if strings.Contains(authURL, "?") {
+		return authURL + "&" + values.Encode()
+	}
+
+	return authURL + "?" + values.Encode()
+}
+
+// Exchange converts the authorization code received on RedirectURL into a token.
+func (c *OAuth2Config) Exchange(ctx context.Context, code string) (*OAuth2Token, error) {
+	return c.retrieveToken(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {c.RedirectURL},
+	})
+}
+
+// Refresh exchanges refreshToken for a new token.
+// The server may not issue a new refresh token (RFC 6749 section 6), refreshToken is kept in that case.
+func (c *OAuth2Config) Refresh(ctx context.Context, refreshToken string) (*OAuth2Token, error) {
+	token, err := c.retrieveToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+
+	return token, nil
+}
+
+// Revoke revokes accessToken.
+func (c *OAuth2Config) Revoke(ctx context.Context, accessToken string) error {
+	revokeURL := c.RevokeURL
+	if revokeURL == "" {
+		revokeURL = OAuth2RevokeURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", revokeURL, strings.NewReader(url.Values{"token": {accessToken}}.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	_, err = c.post(req)
+
+	return err
+}
+
+// retrieveToken posts values to the token endpoint
+func (c *OAuth2Config) retrieveToken(ctx context.Context, values url.Values) (*OAuth2Token, error) {
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = OAuth2TokenURL
+	}
+
+	values.Set("client_id", c.ClientID)
+	values.Set("client_secret", c.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	data, err := c.post(req)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &OAuth2Token{}
+	if err = json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+
+	if token.AccessToken == "" {
+		return nil, &OAuth2Error{Code: "invalid_response", Description: "no access token in response"}
+	}
+
+	if token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// post sends req and returns the response body, failed requests are returned as OAuth2Error
+func (c *OAuth2Config) post(req *http.Request) ([]byte, error) {
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		oauthErr := &OAuth2Error{StatusCode: resp.StatusCode}
+		json.Unmarshal(data, oauthErr)
+		return nil, oauthErr
+	}
+
+	return data, nil
+}
+
+// Error method implementation for OAuth2Error struct
+func (e *OAuth2Error) Error() string {
+	return fmt.Sprintf("oauth2: %d %s: %s", e.StatusCode, e.Code, e.Description)
+}
+
+// HasScope reports whether the token was granted scope.
+func (t *OAuth2Token) HasScope(scope string) bool {
+	for _, s := range strings.FieldsFunc(t.Scope, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expired reports whether the access token is expired, or about to, at now
+func (t *OAuth2Token) expired(now time.Time) bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+
+	return !now.Add(tokenExpiryMargin).Before(t.Expiry)
+}
@@ -0,0 +1,215 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// tokenServer is an OAuth2 token and revoke endpoint, respond answers the token requests
+type tokenServer struct {
+	*httptest.Server
+	t        *testing.T
+	requests []map[string]string
+	revoked  []string
+	respond  func(w http.ResponseWriter, r *http.Request)
+}
+
+func newTokenServer(t *testing.T, respond func(w http.ResponseWriter, r *http.Request)) *tokenServer {
+	s := &tokenServer{t: t, respond: respond}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+func (s *tokenServer) handle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.t.Error(err)
+		return
+	}
+
+	if r.URL.Path == "/oauth/revoke" {
+		s.revoked = append(s.revoked, r.PostForm.Get("token"))
+		return
+	}
+
+	if r.PostForm.Get("client_id") != "client" || r.PostForm.Get("client_secret") != "secret" {
+		s.t.Errorf("client credentials = %q, %q", r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"))
+	}
+
+	form := map[string]string{}
+	for key := range r.PostForm {
+		form[key] = r.PostForm.Get(key)
+	}
+	s.requests = append(s.requests, form)
+
+	w.Header().Set("Content-Type", "application/json")
+	s.respond(w, r)
+}
+
+func (s *tokenServer) config() *OAuth2Config {
+	return &OAuth2Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "https://example.com/callback",
+		TokenURL:     s.URL + "/oauth/token",
+		RevokeURL:    s.URL + "/oauth/revoke",
+	}
+}
+
+// respondToken issues access and refresh tokens numbered after the request count
+func (s *tokenServer) respondToken(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, `{"access_token":"access%d","token_type":"bearer","expires_in":7200,"refresh_token":"refresh%d","scope":"wallet:user:read"}`, len(s.requests), len(s.requests))
+}
+
+func TestOAuth2Exchange(t *testing.T) {
+	var s *tokenServer
+	s = newTokenServer(t, func(w http.ResponseWriter, r *http.Request) { s.respondToken(w, r) })
+	defer s.Close()
+
+	before := time.Now()
+	token, err := s.config().Exchange(context.Background(), "code")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := s.requests[0]
+	if form["grant_type"] != "authorization_code" || form["code"] != "code" || form["redirect_uri"] != "https://example.com/callback" {
+		t.Errorf("form = %v", form)
+	}
+
+	if token.AccessToken != "access1" || token.RefreshToken != "refresh1" || !token.HasScope("wallet:user:read") {
+		t.Errorf("token = %+v", token)
+	}
+
+	if token.Expiry.Before(before.Add(2*time.Hour)) || token.Expiry.After(time.Now().Add(2*time.Hour)) {
+		t.Errorf("expiry = %v, want about 2 hours from now", token.Expiry)
+	}
+}
+
+func TestOAuth2RefreshRotation(t *testing.T) {
+	var s *tokenServer
+	s = newTokenServer(t, func(w http.ResponseWriter, r *http.Request) { s.respondToken(w, r) })
+	defer s.Close()
+
+	now := time.Now()
+	auth := NewOAuth2Auth(s.config(), &OAuth2Token{AccessToken: "access0", RefreshToken: "refresh0", Expiry: now.Add(-time.Second)})
+
+	persisted := []OAuth2Token{}
+	auth.OnTokenRefresh = func(token *OAuth2Token) error {
+		persisted = append(persisted, *token)
+		return nil
+	}
+
+	for i := 1; i <= 2; i++ {
+		req, _ := http.NewRequest("GET", "https://api.coinbase.com/v2/user", nil)
+		if err := auth.Authenticate(req, now.Add(time.Duration(i)*3*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := req.Header.Get("Authorization"), fmt.Sprintf("Bearer access%d", i); got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		if got, want := s.requests[i-1]["refresh_token"], fmt.Sprintf("refresh%d", i-1); got != want || s.requests[i-1]["grant_type"] != "refresh_token" {
+			t.Errorf("refresh request %d = %v, want refresh_token %s", i, s.requests[i-1], want)
+		}
+	}
+
+	if len(persisted) != 2 || persisted[1].RefreshToken != "refresh2" || auth.Token().RefreshToken != "refresh2" {
+		t.Errorf("persisted = %+v, current = %+v", persisted, auth.Token())
+	}
+}
+
+func TestOAuth2RefreshKeepsRefreshToken(t *testing.T) {
+	s := newTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"access1","token_type":"bearer","expires_in":7200}`)
+	})
+	defer s.Close()
+
+	auth := NewOAuth2Auth(s.config(), &OAuth2Token{AccessToken: "access0", RefreshToken: "refresh0"})
+	if err := auth.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if token := auth.Token(); token.AccessToken != "access1" || token.RefreshToken != "refresh0" {
+		t.Errorf("token = %+v", token)
+	}
+}
+
+func TestOAuth2ExpiryMargin(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		expiry  time.Time
+		expired bool
+	}{
+		{time.Time{}, false},
+		{now.Add(-time.Second), true},
+		{now.Add(tokenExpiryMargin - time.Second), true},
+		{now.Add(tokenExpiryMargin), true},
+		{now.Add(tokenExpiryMargin + time.Second), false},
+	}
+
+	for _, test := range tests {
+		token := &OAuth2Token{Expiry: test.expiry}
+		if got := token.expired(now); got != test.expired {
+			t.Errorf("expired with expiry in %v = %v, want %v", test.expiry.Sub(now), got, test.expired)
+		}
+	}
+
+	var s *tokenServer
+	s = newTokenServer(t, func(w http.ResponseWriter, r *http.Request) { s.respondToken(w, r) })
+	defer s.Close()
+
+	auth := NewOAuth2Auth(s.config(), &OAuth2Token{AccessToken: "access0", RefreshToken: "refresh0", Expiry: now.Add(tokenExpiryMargin / 2)})
+	req, _ := http.NewRequest("GET", "https://api.coinbase.com/v2/user", nil)
+	if err := auth.Authenticate(req, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.requests) != 1 || req.Header.Get("Authorization") != "Bearer access1" {
+		t.Errorf("token about to expire was not refreshed: %v", s.requests)
+	}
+}
+
+func TestOAuth2Error(t *testing.T) {
+	s := newTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"invalid_grant","error_description":"The provided authorization grant is invalid"}`)
+	})
+	defer s.Close()
+
+	_, err := s.config().Refresh(context.Background(), "refresh0")
+
+	var oauthErr *OAuth2Error
+	if !errors.As(err, &oauthErr) {
+		t.Fatalf("err = %v, want *OAuth2Error", err)
+	}
+
+	if oauthErr.StatusCode != http.StatusUnauthorized || oauthErr.Code != "invalid_grant" || oauthErr.Description != "The provided authorization grant is invalid" {
+		t.Errorf("err = %+v", oauthErr)
+	}
+}
+
+func TestOAuth2Revoke(t *testing.T) {
+	s := newTokenServer(t, nil)
+	defer s.Close()
+
+	auth := NewOAuth2Auth(s.config(), &OAuth2Token{AccessToken: "access0", RefreshToken: "refresh0"})
+	if err := auth.Revoke(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.revoked) != 1 || s.revoked[0] != "access0" {
+		t.Errorf("revoked = %v", s.revoked)
+	}
+
+	req, _ := http.NewRequest("GET", "https://api.coinbase.com/v2/user", nil)
+	if err := auth.Authenticate(req, time.Now()); err != ErrNoRefreshToken {
+		t.Errorf("Authenticate after Revoke = %v, want %v", err, ErrNoRefreshToken)
+	}
+}
@@ -0,0 +1,195 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrForeignURI is returned when a URI returned by the API points outside of APIBase
+var ErrForeignURI = errors.New("coinbase: URI outside of the API base")
+
+// Sort orders accepted by ListOptions.Order
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// Iterator walks every item of a paginated list endpoint, following
+// Pagination.NextUri transparently until the last page is reached.
+//
+//	it := c.IterateAccounts(ctx)
+//	for it.Next() {
+//		account := it.Value().(Account)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator struct {
+	client  *Client
+	ctx     context.Context
+	uri     string
+	decode  func(data []byte) (interface{}, error)
+	page    []json.RawMessage
+	value   interface{}
+	err     error
+	started bool
+}
+
+// newIterator returns an Iterator starting at uri, decode converts every raw item into its typed value.
+func (c *Client) newIterator(ctx context.Context, uri string, decode func(data []byte) (interface{}, error)) *Iterator {
+	return &Iterator{
+		client: c,
+		ctx:    ctx,
+		uri:    uri,
+		decode: decode,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the following page when needed.
+// It returns false when there are no more items or an error occurred.
+func (it *Iterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || (it.started && it.uri == "") {
+			return false
+		}
+
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		page := []json.RawMessage{}
+
+		pagination, err := it.client.ListPage(it.ctx, it.uri, &page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.started = true
+		it.page = page
+		it.uri = pagination.NextUri
+	}
+
+	value, err := it.decode(it.page[0])
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page = it.page[1:]
+	it.value = value
+
+	return true
+}
+
+// Value returns the current item, it must be type asserted to the resource type of the iterator.
+func (it *Iterator) Value() interface{} {
+	return it.value
+}
+
+// Err returns the first error encountered while iterating, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// All collects the remaining items of every page.
+func (it *Iterator) All() ([]interface{}, error) {
+	values := []interface{}{}
+
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+
+	return values, it.Err()
+}
+
+// ListPage fetches a single page of a list endpoint into v, uri can be either an absolute URL
+// or a Pagination.NextUri / Pagination.PreviousUri value as returned by the API.
+func (c *Client) ListPage(ctx context.Context, uri string, v interface{}) (*Pagination, error) {
+	pagination := &Pagination{}
+
+	u, err := c.resolveURI(uri)
+	if err != nil {
+		return pagination, err
+	}
+
+	req, err := c.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return pagination, err
+	}
+
+	if err = c.SendWithAuth(req, v, pagination); err != nil {
+		return pagination, err
+	}
+
+	return pagination, nil
+}
+
+// resolveURI resolves uri against the scheme and host of APIBase.
+// Pagination URIs are returned as absolute paths, e.g. /v2/accounts?starting_after=...
+// Absolute URIs on another scheme or host are refused, requests are signed and must not leave APIBase.
+func (c *Client) resolveURI(uri string) (string, error) {
+	base, err := url.Parse(c.APIBase)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	resolved := base.ResolveReference(ref)
+	if !strings.EqualFold(resolved.Scheme, base.Scheme) || !strings.EqualFold(resolved.Host, base.Host) {
+		return "", fmt.Errorf("%w: %s", ErrForeignURI, uri)
+	}
+
+	return resolved.String(), nil
+}
+
+// values encodes the options as query string parameters, unset fields are omitted.
+func (o *ListOptions) values() url.Values {
+	values := url.Values{}
+
+	if o == nil {
+		return values
+	}
+
+	if o.Limit > 0 {
+		values.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Order != "" {
+		values.Set("order", o.Order)
+	}
+	if o.StartingAfter != "" {
+		values.Set("starting_after", o.StartingAfter)
+	}
+	if o.EndingBefore != "" {
+		values.Set("ending_before", o.EndingBefore)
+	}
+	for _, expand := range o.Expand {
+		values.Add("expand[]", expand)
+	}
+
+	return values
+}
+
+// withListOptions appends the encoded options to uri.
+func withListOptions(uri string, opts *ListOptions) string {
+	values := opts.values()
+	if len(values) == 0 {
+		return uri
+	}
+
+	if strings.Contains(uri, "?") {
+		return uri + "&" + values.Encode()
+	}
+
+	return uri + "?" + values.Encode()
+}
@@ -0,0 +1,57 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestForeignURIsAreNotFollowed(t *testing.T) {
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent to a foreign host: %s %s, signature %q", r.Method, r.URL, r.Header.Get("CB-ACCESS-SIGN"))
+	}))
+	defer foreign.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/accounts":
+			fmt.Fprintf(w, `{"pagination":{"next_uri":%q},"data":[{"id":"a1"}]}`, foreign.URL+"/v2/accounts?starting_after=a1")
+		case "/v2/users/u1":
+			fmt.Fprint(w, `{"data":{"id":"u1"}}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer api.Close()
+
+	c := NewClient(testAPIKey, testAPISecret)
+	c.APIBase = api.URL + "/v2"
+
+	it := c.IterateAccounts(context.Background(), nil)
+	if !it.Next() || it.Value().(Account).ID != "a1" {
+		t.Fatalf("first account not returned: %v", it.Err())
+	}
+	if it.Next() || !errors.Is(it.Err(), ErrForeignURI) {
+		t.Fatalf("next_uri on a foreign host: %v, want %v", it.Err(), ErrForeignURI)
+	}
+
+	if _, err := c.ListPage(context.Background(), foreign.URL+"/v2/accounts", &[]Account{}); !errors.Is(err, ErrForeignURI) {
+		t.Fatalf("ListPage: %v, want %v", err, ErrForeignURI)
+	}
+
+	if _, err := c.Resolve(context.Background(), ResourceRef{Resource: "user", ResourcePath: foreign.URL + "/v2/users/u1"}); !errors.Is(err, ErrForeignURI) {
+		t.Fatalf("Resolve absolute path: %v, want %v", err, ErrForeignURI)
+	}
+
+	if _, err := c.Resolve(context.Background(), ResourceRef{Resource: "user", ResourcePath: "//" + foreign.Listener.Addr().String() + "/v2/users/u1"}); !errors.Is(err, ErrForeignURI) {
+		t.Fatalf("Resolve scheme-relative path: %v, want %v", err, ErrForeignURI)
+	}
+
+	resource, err := c.Resolve(context.Background(), ResourceRef{Resource: "user", ResourcePath: "/v2/users/u1"})
+	if err != nil || resource.(*User).ID != "u1" {
+		t.Fatalf("Resolve relative path: %v, %v", resource, err)
+	}
+}
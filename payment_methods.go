@@ -2,17 +2,18 @@ package coinbase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
 // ListPaymentMethods Lists current user’s payment methods.
 // Endpoint: GET /payment-methods
-func (c *Client) ListPaymentMethods(ctx context.Context) (*[]PaymentMethod, *Pagination, error) {
+func (c *Client) ListPaymentMethods(ctx context.Context, opts *ListOptions) (*[]PaymentMethod, *Pagination, error) {
 	paymentMethods := &[]PaymentMethod{}
 
 	pagination := &Pagination{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s", c.APIBase, "payment-methods"), nil)
+	req, err := c.NewRequest(ctx, "GET", withListOptions(fmt.Sprintf("%s/%s", c.APIBase, "payment-methods"), opts), nil)
 	if err != nil {
 		return paymentMethods, pagination, err
 	}
@@ -24,6 +25,28 @@ func (c *Client) ListPaymentMethods(ctx context.Context) (*[]PaymentMethod, *Pag
 	return paymentMethods, pagination, nil
 }
 
+// IteratePaymentMethods Returns an Iterator over all current user’s payment methods, following pagination automatically.
+// Every Value is a PaymentMethod.
+func (c *Client) IteratePaymentMethods(ctx context.Context, opts *ListOptions) *Iterator {
+	return c.newIterator(ctx, withListOptions(fmt.Sprintf("%s/%s", c.APIBase, "payment-methods"), opts), func(data []byte) (interface{}, error) {
+		paymentMethod := PaymentMethod{}
+		err := json.Unmarshal(data, &paymentMethod)
+		return paymentMethod, err
+	})
+}
+
+// ListAllPaymentMethods Lists all current user’s payment methods, fetching every page.
+func (c *Client) ListAllPaymentMethods(ctx context.Context, opts *ListOptions) (*[]PaymentMethod, error) {
+	paymentMethods := &[]PaymentMethod{}
+
+	it := c.IteratePaymentMethods(ctx, opts)
+	for it.Next() {
+		*paymentMethods = append(*paymentMethods, it.Value().(PaymentMethod))
+	}
+
+	return paymentMethods, it.Err()
+}
+
 // ShowPaymentMethod Show current user’s payment method.
 // Endpoint: GET /payment-methods/:payment_method_id/
 func (c *Client) ShowPaymentMethod(ctx context.Context, paymentMethodID string) (*PaymentMethod, error) {
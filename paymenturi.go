@@ -0,0 +1,260 @@
+package coinbase
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/AlessandroSechi/go-coinbase/qrcode"
+)
+
+// weiDecimals is the number of decimals of ether, EIP-681 amounts are in wei
+const weiDecimals = 18
+
+// Bounds of EIP-681 values, uint256 numbers of at most 78 digits, checked before parsing untrusted input
+const (
+	maxWeiBits   = 256
+	maxWeiLength = 100
+)
+
+// Payment URI errors
+var (
+	ErrInvalidPaymentURI     = errors.New("coinbase: invalid payment URI")
+	ErrUnsupportedPaymentURI = errors.New("coinbase: unsupported payment URI")
+)
+
+// paymentScheme describes the URI scheme of a network
+type paymentScheme struct {
+	scheme   string
+	currency string
+	chainID  int // EIP-681 chain ID, 0 for BIP21 schemes and the ethereum mainnet
+}
+
+// paymentSchemes maps networks to their URI scheme
+var paymentSchemes = map[string]paymentScheme{
+	"bitcoin":     {scheme: "bitcoin", currency: "BTC"},
+	"litecoin":    {scheme: "litecoin", currency: "LTC"},
+	"bitcoincash": {scheme: "bitcoincash", currency: "BCH"},
+	"ethereum":    {scheme: "ethereum", currency: "ETH"},
+	"base":        {scheme: "ethereum", currency: "ETH", chainID: 8453},
+	"arbitrum":    {scheme: "ethereum", currency: "ETH", chainID: 42161},
+	"optimism":    {scheme: "ethereum", currency: "ETH", chainID: 10},
+	"polygon":     {scheme: "ethereum", currency: "POL", chainID: 137},
+}
+
+// PaymentURI returns a BIP21 (bitcoin:, litecoin:, bitcoincash:) or EIP-681 (ethereum:) URI paying the address,
+// opts is optional. EIP-681 URIs carry the amount in wei and ignore the label and message.
+func (a *Address) PaymentURI(opts *PaymentURIOptions) (string, error) {
+	scheme, ok := paymentSchemes[strings.ToLower(a.Network)]
+	if !ok {
+		return "", fmt.Errorf("%w: network %q", ErrUnsupportedPaymentURI, a.Network)
+	}
+
+	if opts == nil {
+		opts = &PaymentURIOptions{}
+	}
+
+	if opts.Amount != nil && opts.Amount.Sign() < 0 {
+		return "", fmt.Errorf("%w: negative amount %s", ErrInvalidPaymentURI, opts.Amount)
+	}
+
+	if scheme.scheme == "ethereum" {
+		return ethereumURI(a.Address, scheme.chainID, opts.Amount)
+	}
+
+	// CashAddr addresses may already carry the scheme as prefix
+	address := a.Address
+	if strings.HasPrefix(strings.ToLower(address), scheme.scheme+":") {
+		address = address[len(scheme.scheme)+1:]
+	}
+
+	params := []string{}
+	if opts.Amount != nil {
+		params = append(params, "amount="+opts.Amount.trim().String())
+	}
+	if opts.Label != "" {
+		params = append(params, "label="+uriEscape(opts.Label))
+	}
+	if opts.Message != "" {
+		params = append(params, "message="+uriEscape(opts.Message))
+	}
+
+	uri := scheme.scheme + ":" + address
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+
+	return uri, nil
+}
+
+// QRCode encodes the payment URI of the address in a QR code, or the bare address if its network has no URI scheme.
+// Render it with PNG, SVG or Unicode.
+func (a *Address) QRCode(opts *PaymentURIOptions, level qrcode.Level) (*qrcode.Code, error) {
+	uri, err := a.PaymentURI(opts)
+	if errors.Is(err, ErrUnsupportedPaymentURI) {
+		uri, err = a.Address, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return qrcode.Encode(uri, level)
+}
+
+// ethereumURI returns an EIP-681 URI transferring amount ether to address
+func ethereumURI(address string, chainID int, amount *Decimal) (string, error) {
+	uri := "ethereum:" + address
+	if chainID != 0 {
+		uri += "@" + strconv.Itoa(chainID)
+	}
+
+	if amount != nil {
+		wei := amount.Mul(NewDecimal(1, -weiDecimals))
+		if wei.Cmp(wei.Truncate(0)) != 0 {
+			return "", fmt.Errorf("%w: amount %s has more than %d decimals", ErrInvalidPaymentURI, amount, weiDecimals)
+		}
+		uri += "?value=" + wei.Truncate(0).String()
+	}
+
+	return uri, nil
+}
+
+// uriEscape percent-encodes s for a URI query, spaces included
+func uriEscape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+// ParsePaymentURI parses a BIP21 or EIP-681 payment URI into a send, ready for SendMoney.
+// The message, or the label if there is none, becomes the description. EIP-681 token transfers and
+// BIP21 URIs with unknown required (req-) parameters are refused with ErrUnsupportedPaymentURI.
+func ParsePaymentURI(uri string) (*SendMoney, error) {
+	i := strings.IndexByte(uri, ':')
+	if i < 0 {
+		return nil, fmt.Errorf("%w: missing scheme in %q", ErrInvalidPaymentURI, uri)
+	}
+
+	scheme, rest := strings.ToLower(uri[:i]), uri[i+1:]
+
+	address, rawQuery := rest, ""
+	if j := strings.IndexByte(rest, '?'); j >= 0 {
+		address, rawQuery = rest[:j], rest[j+1:]
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPaymentURI, err)
+	}
+
+	if scheme == "ethereum" {
+		return parseEthereumURI(address, query)
+	}
+
+	for network, s := range paymentSchemes {
+		if s.scheme == scheme && s.chainID == 0 {
+			return parseBIP21(network, s, strings.TrimPrefix(address, "//"), query)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: scheme %q", ErrUnsupportedPaymentURI, scheme)
+}
+
+// parseBIP21 builds the send of a BIP21 URI
+func parseBIP21(network string, scheme paymentScheme, address string, query url.Values) (*SendMoney, error) {
+	if address == "" {
+		return nil, fmt.Errorf("%w: missing address", ErrInvalidPaymentURI)
+	}
+
+	for key := range query {
+		if strings.HasPrefix(key, "req-") {
+			return nil, fmt.Errorf("%w: required parameter %q", ErrUnsupportedPaymentURI, key)
+		}
+	}
+
+	sendData := &SendMoney{
+		Type:        string(TransactionTypeSend),
+		To:          address,
+		Currency:    scheme.currency,
+		Network:     network,
+		Description: query.Get("message"),
+	}
+
+	if sendData.Description == "" {
+		sendData.Description = query.Get("label")
+	}
+
+	if amount := query.Get("amount"); amount != "" {
+		// BIP21 amounts are plain decimals, exponents are refused before parsing
+		if strings.ContainsAny(amount, "eE") {
+			return nil, fmt.Errorf("%w: amount %q", ErrInvalidPaymentURI, amount)
+		}
+
+		d, err := ParseDecimal(amount)
+		if err != nil || d.Sign() < 0 {
+			return nil, fmt.Errorf("%w: amount %q", ErrInvalidPaymentURI, amount)
+		}
+		sendData.Amount = d
+	}
+
+	return sendData, nil
+}
+
+// parseEthereumURI builds the send of an EIP-681 URI: ethereum:[pay-]address[@chain_id][/function]?value=wei
+func parseEthereumURI(target string, query url.Values) (*SendMoney, error) {
+	target = strings.TrimPrefix(target, "pay-")
+
+	if i := strings.IndexByte(target, '/'); i >= 0 {
+		return nil, fmt.Errorf("%w: function call %q", ErrUnsupportedPaymentURI, target[i+1:])
+	}
+
+	address, chainID := target, 0
+	if i := strings.IndexByte(target, '@'); i >= 0 {
+		id, err := strconv.Atoi(target[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: chain ID %q", ErrInvalidPaymentURI, target[i+1:])
+		}
+		address, chainID = target[:i], id
+	}
+
+	if address == "" {
+		return nil, fmt.Errorf("%w: missing address", ErrInvalidPaymentURI)
+	}
+
+	// The mainnet may be explicit
+	if chainID == 1 {
+		chainID = 0
+	}
+
+	network, scheme := "", paymentScheme{}
+	for n, s := range paymentSchemes {
+		if s.scheme == "ethereum" && s.chainID == chainID {
+			network, scheme = n, s
+		}
+	}
+
+	if network == "" {
+		return nil, fmt.Errorf("%w: chain ID %d", ErrUnsupportedPaymentURI, chainID)
+	}
+
+	sendData := &SendMoney{
+		Type:     string(TransactionTypeSend),
+		To:       address,
+		Currency: scheme.currency,
+		Network:  network,
+	}
+
+	if value := query.Get("value"); value != "" {
+		if len(value) > maxWeiLength {
+			return nil, fmt.Errorf("%w: value too long", ErrInvalidPaymentURI)
+		}
+
+		wei, err := ParseDecimal(value)
+		if err != nil || wei.Sign() < 0 || wei.Cmp(wei.Truncate(0)) != 0 || wei.Truncate(0).int().BitLen() > maxWeiBits {
+			return nil, fmt.Errorf("%w: value %q", ErrInvalidPaymentURI, value)
+		}
+		sendData.Amount = (&Decimal{unscaled: wei.Truncate(0).int(), scale: weiDecimals}).trim()
+	}
+
+	return sendData, nil
+}
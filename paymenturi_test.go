@@ -0,0 +1,144 @@
+package coinbase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPaymentURIRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  Address
+		opts     *PaymentURIOptions
+		uri      string
+		to       string
+		currency string
+		amount   string
+		desc     string
+	}{
+		{
+			name:     "bitcoin",
+			address:  Address{Address: "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", Network: "bitcoin"},
+			opts:     &PaymentURIOptions{Amount: MustParseDecimal("0.5")},
+			uri:      "bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=0.5",
+			to:       "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+			currency: "BTC",
+			amount:   "0.5",
+		},
+		{
+			name:     "bitcoin cash without prefix",
+			address:  Address{Address: "qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", Network: "bitcoincash"},
+			uri:      "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+			to:       "qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+			currency: "BCH",
+		},
+		{
+			name:     "bitcoin cash with prefix",
+			address:  Address{Address: "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", Network: "bitcoincash"},
+			opts:     &PaymentURIOptions{Amount: MustParseDecimal("1.25")},
+			uri:      "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a?amount=1.25",
+			to:       "qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+			currency: "BCH",
+			amount:   "1.25",
+		},
+		{
+			name:     "ethereum mainnet",
+			address:  Address{Address: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", Network: "ethereum"},
+			opts:     &PaymentURIOptions{Amount: MustParseDecimal("0.01")},
+			uri:      "ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359?value=10000000000000000",
+			to:       "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+			currency: "ETH",
+			amount:   "0.01",
+		},
+		{
+			name:     "ethereum on base",
+			address:  Address{Address: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", Network: "base"},
+			opts:     &PaymentURIOptions{Amount: MustParseDecimal("2")},
+			uri:      "ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359@8453?value=2000000000000000000",
+			to:       "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+			currency: "ETH",
+			amount:   "2",
+		},
+		{
+			name:     "amount trimming",
+			address:  Address{Address: "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", Network: "bitcoin"},
+			opts:     &PaymentURIOptions{Amount: MustParseDecimal("0.01000000")},
+			uri:      "bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=0.01",
+			to:       "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+			currency: "BTC",
+			amount:   "0.01",
+		},
+		{
+			name:     "label and message escaping",
+			address:  Address{Address: "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", Network: "bitcoin"},
+			opts:     &PaymentURIOptions{Label: "Shop & Co", Message: "Order #42 ü"},
+			uri:      "bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?label=Shop%20%26%20Co&message=Order%20%2342%20%C3%BC",
+			to:       "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+			currency: "BTC",
+			desc:     "Order #42 ü",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			uri, err := test.address.PaymentURI(test.opts)
+			if err != nil {
+				t.Fatalf("PaymentURI: %v", err)
+			}
+			if uri != test.uri {
+				t.Fatalf("PaymentURI = %q, want %q", uri, test.uri)
+			}
+
+			sendData, err := ParsePaymentURI(uri)
+			if err != nil {
+				t.Fatalf("ParsePaymentURI: %v", err)
+			}
+			if sendData.To != test.to || sendData.Currency != test.currency || sendData.Network != test.address.Network || sendData.Description != test.desc {
+				t.Fatalf("ParsePaymentURI = %+v", sendData)
+			}
+
+			switch {
+			case test.amount == "" && sendData.Amount != nil:
+				t.Fatalf("amount = %s, want none", sendData.Amount)
+			case test.amount != "" && (sendData.Amount == nil || sendData.Amount.String() != test.amount):
+				t.Fatalf("amount = %s, want %s", sendData.Amount, test.amount)
+			}
+		})
+	}
+}
+
+func TestParsePaymentURIErrors(t *testing.T) {
+	tests := []struct {
+		uri string
+		err error
+	}{
+		{"bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?req-somethingnew=1", ErrUnsupportedPaymentURI},
+		{"ethereum:0x89205A3A3b2A69De6Dbf7f01ED13B2108B2c43e7/transfer?address=0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359&uint256=1", ErrUnsupportedPaymentURI},
+		{"ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359@999999", ErrUnsupportedPaymentURI},
+		{"dogecoin:DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L", ErrUnsupportedPaymentURI},
+		{"bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=1e20000000", ErrInvalidPaymentURI},
+		{"bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=-1", ErrInvalidPaymentURI},
+		{"ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359?value=1e20000000", ErrInvalidPaymentURI},
+		{"ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359?value=1e100", ErrInvalidPaymentURI},
+		{"ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359?value=0.5", ErrInvalidPaymentURI},
+		{"bitcoin:", ErrInvalidPaymentURI},
+		{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", ErrInvalidPaymentURI},
+	}
+
+	for _, test := range tests {
+		if _, err := ParsePaymentURI(test.uri); !errors.Is(err, test.err) {
+			t.Errorf("ParsePaymentURI(%q) = %v, want %v", test.uri, err, test.err)
+		}
+	}
+}
+
+func TestParsePaymentURIExponentValue(t *testing.T) {
+	sendData, err := ParsePaymentURI("ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359?value=1.5e18")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sendData.Amount.String() != "1.5" {
+		t.Fatalf("amount = %s, want 1.5", sendData.Amount)
+	}
+}
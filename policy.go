@@ -0,0 +1,379 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPolicyCurrency is the currency limits are expressed in when Policy.NativeCurrency is not set
+const DefaultPolicyCurrency = "USD"
+
+// policyAnyCurrency is the key of Policy.Limits applying to currencies without their own limit
+const policyAnyCurrency = "*"
+
+// ErrPolicyViolation is matched by every PolicyViolation with errors.Is
+var ErrPolicyViolation = errors.New("coinbase: policy violation")
+
+// ErrInvalidPolicyLimit is returned when a key of Policy.Limits is not a currency code or "*"
+var ErrInvalidPolicyLimit = errors.New("coinbase: invalid policy limit")
+
+// Rules of a Policy, reported in PolicyViolation.Rule
+const (
+	PolicyRulePerTransaction = "per_transaction"
+	PolicyRuleDaily          = "daily"
+	PolicyRuleWeekly         = "weekly"
+	PolicyRuleDestination    = "destination"
+	PolicyRuleCurrency       = "currency"
+	PolicyRuleWindow         = "window"
+)
+
+// Policy guards money-moving calls: sends, transfers, buys, sells, deposits and withdrawals are checked
+// against it before the request leaves the process. Amounts are valued in NativeCurrency using the current exchange rates.
+// Daily and weekly caps are rolling windows over the amounts moved through this Policy, which keeps them in memory.
+type Policy struct {
+	NativeCurrency      string                 // Currency limits are expressed in, defaults to DefaultPolicyCurrency
+	Limits              map[string]PolicyLimit // Per currency of the moved amount, "*" applies to currencies without their own limit
+	AllowedDestinations []string               // If set, sends are only allowed to these addresses or emails
+	BlockedCurrencies   []string               // Currencies which can never be moved
+	Windows             []PolicyWindow         // If set, money can only be moved within one of them
+	Location            *time.Location         // Location of Windows, defaults to UTC
+	Clock               Clock                  // Defaults to the system clock
+
+	mu    sync.Mutex
+	spent []policySpend
+}
+
+// policySpend is an amount moved, or about to be, valued in the native currency
+type policySpend struct {
+	currency string
+	amount   *Decimal
+	at       time.Time
+}
+
+// Error method implementation for PolicyViolation struct
+func (v *PolicyViolation) Error() string {
+	return fmt.Sprintf("coinbase: policy violation (%s): %s %s on account %s: %s", v.Rule, v.Action.Operation, v.Action.Amount, v.Action.AccountID, v.Reason)
+}
+
+// Is reports whether target is ErrPolicyViolation
+func (v *PolicyViolation) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// native returns the currency limits are expressed in
+func (p *Policy) native() string {
+	if p.NativeCurrency == "" {
+		return DefaultPolicyCurrency
+	}
+
+	return strings.ToUpper(p.NativeCurrency)
+}
+
+// now returns the current time of the Policy clock
+func (p *Policy) now() time.Time {
+	if p.Clock == nil {
+		return time.Now()
+	}
+
+	return p.Clock.Now()
+}
+
+// limit returns the limit applying to currency, keys of Limits are matched case-insensitively.
+// Keys which are neither "*" nor a currency code are refused, so that a typo cannot disable a cap.
+func (p *Policy) limit(currency string) (PolicyLimit, bool, error) {
+	var (
+		limit, fallback    PolicyLimit
+		found, hasFallback bool
+	)
+
+	for key, l := range p.Limits {
+		switch {
+		case key == policyAnyCurrency:
+			fallback, hasFallback = l, true
+		case !currencyCode(key):
+			return PolicyLimit{}, false, fmt.Errorf("%w: %q", ErrInvalidPolicyLimit, key)
+		case strings.EqualFold(key, currency):
+			if found {
+				return PolicyLimit{}, false, fmt.Errorf("%w: %q defined twice", ErrInvalidPolicyLimit, key)
+			}
+			limit, found = l, true
+		}
+	}
+
+	if found {
+		return limit, true, nil
+	}
+
+	return fallback, hasFallback, nil
+}
+
+// currencyCode reports whether s looks like a currency code, such as BTC or USDC
+func currencyCode(s string) bool {
+	if len(s) < 2 || len(s) > 10 {
+		return false
+	}
+
+	for _, r := range s {
+		if !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Check reports whether action is allowed, without recording it.
+func (p *Policy) Check(ctx context.Context, c *Client, action PolicyAction) error {
+	_, err := p.check(ctx, c, action, false)
+	return err
+}
+
+// check validates action and, if reserve is set, records its amount against the rolling caps
+func (p *Policy) check(ctx context.Context, c *Client, action PolicyAction, reserve bool) (*policySpend, error) {
+	action.Amount.Currency = strings.ToUpper(action.Amount.Currency)
+	currency := action.Amount.Currency
+
+	violation := func(rule string, format string, args ...interface{}) error {
+		return &PolicyViolation{Rule: rule, Action: action, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	for _, blocked := range p.BlockedCurrencies {
+		if strings.EqualFold(blocked, currency) {
+			return nil, violation(PolicyRuleCurrency, "currency %s is blocked", currency)
+		}
+	}
+
+	if action.Operation == TransactionTypeSend && action.To != "" && len(p.AllowedDestinations) > 0 && !p.allowedDestination(action.To) {
+		return nil, violation(PolicyRuleDestination, "destination %s is not allowed", action.To)
+	}
+
+	now := p.now()
+	if !p.inWindow(now) {
+		return nil, violation(PolicyRuleWindow, "outside of the allowed windows at %s", now.In(p.location()).Format(time.RFC1123))
+	}
+
+	limit, ok, err := p.limit(currency)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	value, err := p.value(ctx, c, action.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit.PerTransaction != nil && value.Cmp(limit.PerTransaction) > 0 {
+		return nil, violation(PolicyRulePerTransaction, "%s %s above the limit of %s %s", value, p.native(), limit.PerTransaction, p.native())
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.prune(now)
+
+	if limit.Daily != nil {
+		if total := p.total(currency, now.Add(-24*time.Hour)).Add(value); total.Cmp(limit.Daily) > 0 {
+			return nil, violation(PolicyRuleDaily, "%s %s in the last 24 hours above the limit of %s %s", total, p.native(), limit.Daily, p.native())
+		}
+	}
+
+	if limit.Weekly != nil {
+		if total := p.total(currency, now.Add(-7*24*time.Hour)).Add(value); total.Cmp(limit.Weekly) > 0 {
+			return nil, violation(PolicyRuleWeekly, "%s %s in the last 7 days above the limit of %s %s", total, p.native(), limit.Weekly, p.native())
+		}
+	}
+
+	if !reserve {
+		return nil, nil
+	}
+
+	// Reserved before sending, so that concurrent calls cannot exceed the caps together
+	spend := &policySpend{currency: currency, amount: value, at: now}
+	p.spent = append(p.spent, *spend)
+
+	return spend, nil
+}
+
+// release removes a reserved amount, when the call failed
+func (p *Policy) release(spend *policySpend) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, s := range p.spent {
+		if s.currency == spend.currency && s.at.Equal(spend.at) && s.amount.Cmp(spend.amount) == 0 {
+			p.spent = append(p.spent[:i], p.spent[i+1:]...)
+			return
+		}
+	}
+}
+
+// prune forgets amounts older than the weekly window
+func (p *Policy) prune(now time.Time) {
+	since := now.Add(-7 * 24 * time.Hour)
+
+	kept := p.spent[:0]
+	for _, s := range p.spent {
+		if s.at.After(since) {
+			kept = append(kept, s)
+		}
+	}
+
+	p.spent = kept
+}
+
+// total sums the amounts of currency moved after since
+func (p *Policy) total(currency string, since time.Time) *Decimal {
+	var total *Decimal
+	for _, s := range p.spent {
+		if s.currency == currency && s.at.After(since) {
+			total = total.Add(s.amount)
+		}
+	}
+
+	return total
+}
+
+// value returns amount valued in the native currency, only the exchange rate of its currency is needed
+func (p *Policy) value(ctx context.Context, c *Client, amount Money) (*Decimal, error) {
+	if amount.Currency == p.native() {
+		return amount.Amount.Abs(), nil
+	}
+
+	exchangeRates, err := c.ListExchangeRates(ctx, p.native())
+	if err != nil {
+		return nil, err
+	}
+
+	rate, ok := exchangeRates.Rates[amount.Currency]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, amount.Currency)
+	}
+
+	r, err := ParseDecimal(rate)
+	if err != nil || r.Sign() <= 0 {
+		return nil, fmt.Errorf("coinbase: invalid exchange rate %q for %s", rate, amount.Currency)
+	}
+
+	return amount.Amount.Abs().Quo(r, 8)
+}
+
+// allowedDestination reports whether to is in AllowedDestinations, emails are compared case-insensitively
+func (p *Policy) allowedDestination(to string) bool {
+	for _, allowed := range p.AllowedDestinations {
+		if allowed == to || (strings.Contains(to, "@") && strings.EqualFold(allowed, to)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// location returns the location of the windows
+func (p *Policy) location() *time.Location {
+	if p.Location == nil {
+		return time.UTC
+	}
+
+	return p.Location
+}
+
+// inWindow reports whether t is within one of the windows
+func (p *Policy) inWindow(t time.Time) bool {
+	if len(p.Windows) == 0 {
+		return true
+	}
+
+	t = t.In(p.location())
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	for _, window := range p.Windows {
+		if window.contains(t.Weekday(), offset) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains reports whether offset since midnight of day is within the window.
+// A window whose To is not after From spans midnight, it starts on one of Days and ends the next day.
+func (w PolicyWindow) contains(day time.Weekday, offset time.Duration) bool {
+	if w.From < w.To {
+		return w.onDay(day) && offset >= w.From && offset < w.To
+	}
+
+	return (w.onDay(day) && offset >= w.From) || (w.onDay((day+6)%7) && offset < w.To)
+}
+
+// onDay reports whether the window applies on day
+func (w PolicyWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// guard checks action against the Policy of the client and runs send if it is allowed.
+// Every decision is logged to the client Log, the amount counts against the rolling caps only if send succeeds.
+func (c *Client) guard(ctx context.Context, action PolicyAction, send func() error) error {
+	if c.Policy == nil {
+		return send()
+	}
+
+	spend, err := c.Policy.check(ctx, c, action, true)
+	c.logPolicy(action, err)
+	if err != nil {
+		return err
+	}
+
+	if err = send(); err != nil && spend != nil {
+		c.Policy.release(spend)
+	}
+
+	return err
+}
+
+// logPolicy writes a policy decision to the client Log
+func (c *Client) logPolicy(action PolicyAction, err error) {
+	if c.Log == nil {
+		return
+	}
+
+	decision := "allowed"
+	if err != nil {
+		decision = "denied: " + err.Error()
+	}
+
+	c.Log.Write([]byte(fmt.Sprintf("Policy: %s %s on account %s to %q %s\n", action.Operation, action.Amount, action.AccountID, action.To, decision)))
+}
+
+// buyAction returns the PolicyAction of a buy, valued by its amount or, if not set, its total
+func buyAction(accountID string, buyData PlaceBuy) PolicyAction {
+	amount := buyData.Amount
+	if amount == nil {
+		amount = buyData.Total
+	}
+
+	return PolicyAction{Operation: TransactionTypeBuy, AccountID: accountID, Amount: Money{Amount: amount, Currency: buyData.Currency}}
+}
+
+// sellAction returns the PolicyAction of a sell, valued by its amount or, if not set, its total
+func sellAction(accountID string, sellData PlaceSell) PolicyAction {
+	amount := sellData.Amount
+	if amount == nil {
+		amount = sellData.Total
+	}
+
+	return PolicyAction{Operation: TransactionTypeSell, AccountID: accountID, Amount: Money{Amount: amount, Currency: sellData.Currency}}
+}
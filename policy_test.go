@@ -0,0 +1,275 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock only moving when advanced
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- f.now
+		return c
+	}
+
+	f.timers = append(f.timers, fakeTimer{at: f.now.Add(d), c: c})
+
+	return c
+}
+
+// Advance moves the clock forward, firing the timers which are due
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+
+	pending := f.timers[:0]
+	for _, timer := range f.timers {
+		if timer.at.After(f.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- f.now
+	}
+	f.timers = pending
+}
+
+// newRatesServer serves the exchange rates of USD: 1 BTC is 50000 USD, 1 EUR is 2 USD
+func newRatesServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/exchange-rates" || r.URL.Query().Get("currency") != "USD" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"currency":"USD","rates":{"BTC":"0.00002","EUR":"0.5","BAD":"0"}}}`)
+	}))
+}
+
+func newPolicyClient(server *httptest.Server, policy *Policy) *Client {
+	c := NewClient(testAPIKey, testAPISecret)
+	c.APIBase = server.URL
+	c.Policy = policy
+
+	return c
+}
+
+// policyRule returns the rule of the violation err, or an empty string
+func policyRule(err error) string {
+	var violation *PolicyViolation
+	if errors.As(err, &violation) {
+		return violation.Rule
+	}
+
+	return ""
+}
+
+func sendAction(amount string, currency string, to string) PolicyAction {
+	return PolicyAction{Operation: TransactionTypeSend, AccountID: "account", Amount: Money{Amount: MustParseDecimal(amount), Currency: currency}, To: to}
+}
+
+func succeed() error { return nil }
+
+func TestPolicyPerTransaction(t *testing.T) {
+	server := newRatesServer(t)
+	defer server.Close()
+
+	c := newPolicyClient(server, &Policy{
+		Limits: map[string]PolicyLimit{"btc": {PerTransaction: MustParseDecimal("1000")}},
+	})
+
+	if err := c.guard(context.Background(), sendAction("0.01", "BTC", "address"), succeed); err != nil {
+		t.Fatalf("500 USD: %v", err)
+	}
+
+	err := c.guard(context.Background(), sendAction("0.03", "btc", "address"), succeed)
+	if !errors.Is(err, ErrPolicyViolation) || policyRule(err) != PolicyRulePerTransaction {
+		t.Fatalf("1500 USD: %v, want a %s violation", err, PolicyRulePerTransaction)
+	}
+
+	// Currencies without limit are not capped
+	if err = c.guard(context.Background(), sendAction("1000", "EUR", "address"), succeed); err != nil {
+		t.Fatalf("EUR: %v", err)
+	}
+}
+
+func TestPolicyDailyAndWeekly(t *testing.T) {
+	server := newRatesServer(t)
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC))
+	c := newPolicyClient(server, &Policy{
+		Limits: map[string]PolicyLimit{"*": {Daily: MustParseDecimal("100"), Weekly: MustParseDecimal("250")}},
+		Clock:  clock,
+	})
+
+	steps := []struct {
+		advance time.Duration
+		amount  string
+		rule    string
+	}{
+		{0, "60", ""},
+		{time.Hour, "50", PolicyRuleDaily},
+		{0, "40", ""},
+		{24 * time.Hour, "100", ""},
+		{24 * time.Hour, "100", PolicyRuleWeekly},
+		{0, "50", ""},
+		{6 * 24 * time.Hour, "100", ""},
+	}
+
+	for i, step := range steps {
+		clock.Advance(step.advance)
+
+		err := c.guard(context.Background(), sendAction(step.amount, "USD", "address"), succeed)
+		if policyRule(err) != step.rule || (step.rule == "" && err != nil) {
+			t.Fatalf("step %d, %s USD: %v, want rule %q", i, step.amount, err, step.rule)
+		}
+	}
+}
+
+func TestPolicyReleaseOnFailedSend(t *testing.T) {
+	server := newRatesServer(t)
+	defer server.Close()
+
+	c := newPolicyClient(server, &Policy{
+		Limits: map[string]PolicyLimit{"EUR": {Daily: MustParseDecimal("100")}},
+	})
+
+	failure := errors.New("send failed")
+	if err := c.guard(context.Background(), sendAction("40", "EUR", "address"), func() error { return failure }); err != failure {
+		t.Fatalf("failed send: %v", err)
+	}
+
+	// 80 USD, counted once the failed 80 USD were released
+	if err := c.guard(context.Background(), sendAction("40", "EUR", "address"), succeed); err != nil {
+		t.Fatalf("after release: %v", err)
+	}
+
+	if err := c.guard(context.Background(), sendAction("15", "EUR", "address"), succeed); policyRule(err) != PolicyRuleDaily {
+		t.Fatalf("above cap: %v, want a %s violation", err, PolicyRuleDaily)
+	}
+}
+
+func TestPolicyWindows(t *testing.T) {
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	clock := newFakeClock(time.Time{})
+
+	policy := &Policy{
+		Windows:  []PolicyWindow{{Days: []time.Weekday{time.Friday}, From: 22 * time.Hour, To: 2 * time.Hour}},
+		Location: zone,
+		Clock:    clock,
+	}
+
+	tests := []struct {
+		at      time.Time
+		allowed bool
+	}{
+		{time.Date(2024, 3, 8, 21, 59, 0, 0, zone), false}, // Friday, before the window
+		{time.Date(2024, 3, 8, 22, 0, 0, 0, zone), true},
+		{time.Date(2024, 3, 8, 23, 30, 0, 0, zone), true},
+		{time.Date(2024, 3, 9, 1, 59, 0, 0, zone), true}, // Saturday, window started on Friday
+		{time.Date(2024, 3, 9, 2, 0, 0, 0, zone), false},
+		{time.Date(2024, 3, 9, 23, 0, 0, 0, zone), false},     // Saturday night is not a Friday
+		{time.Date(2024, 3, 8, 1, 0, 0, 0, zone), false},      // Friday morning, the window started on Thursday
+		{time.Date(2024, 3, 8, 20, 30, 0, 0, time.UTC), true}, // 22:30 in the window location
+	}
+
+	for _, test := range tests {
+		clock.now = test.at
+
+		err := policy.Check(context.Background(), nil, sendAction("1", "USD", "address"))
+		if allowed := err == nil; allowed != test.allowed || (!allowed && policyRule(err) != PolicyRuleWindow) {
+			t.Errorf("at %s: %v, want allowed %v", test.at, err, test.allowed)
+		}
+	}
+}
+
+func TestPolicyBlockedCurrency(t *testing.T) {
+	policy := &Policy{BlockedCurrencies: []string{"xrp"}}
+
+	if err := policy.Check(context.Background(), nil, sendAction("1", "XRP", "address")); policyRule(err) != PolicyRuleCurrency {
+		t.Fatalf("XRP: %v, want a %s violation", err, PolicyRuleCurrency)
+	}
+
+	if err := policy.Check(context.Background(), nil, sendAction("1", "BTC", "address")); err != nil {
+		t.Fatalf("BTC: %v", err)
+	}
+}
+
+func TestPolicyAllowedDestinations(t *testing.T) {
+	policy := &Policy{AllowedDestinations: []string{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", "Friend@Example.com"}}
+
+	tests := []struct {
+		action  PolicyAction
+		allowed bool
+	}{
+		{sendAction("1", "BTC", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"), true},
+		{sendAction("1", "BTC", "friend@example.com"), true},
+		{sendAction("1", "BTC", "bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh"), false},
+		{PolicyAction{Operation: TransactionTypeTransfer, AccountID: "account", Amount: Money{Amount: MustParseDecimal("1"), Currency: "BTC"}, To: "my-other-account-id"}, true},
+	}
+
+	for _, test := range tests {
+		err := policy.Check(context.Background(), nil, test.action)
+		if allowed := err == nil; allowed != test.allowed || (!allowed && policyRule(err) != PolicyRuleDestination) {
+			t.Errorf("%s to %s: %v, want allowed %v", test.action.Operation, test.action.To, err, test.allowed)
+		}
+	}
+}
+
+func TestPolicyInvalidLimit(t *testing.T) {
+	policy := &Policy{Limits: map[string]PolicyLimit{"bit coin": {PerTransaction: MustParseDecimal("1")}}}
+
+	if err := policy.Check(context.Background(), nil, sendAction("1", "USD", "address")); !errors.Is(err, ErrInvalidPolicyLimit) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidPolicyLimit)
+	}
+}
+
+func TestPolicyMissingRate(t *testing.T) {
+	server := newRatesServer(t)
+	defer server.Close()
+
+	c := newPolicyClient(server, &Policy{Limits: map[string]PolicyLimit{"*": {PerTransaction: MustParseDecimal("1000")}}})
+
+	// The zero rate of BAD does not prevent valuing other currencies
+	if err := c.guard(context.Background(), sendAction("1", "EUR", "address"), succeed); err != nil {
+		t.Fatalf("EUR: %v", err)
+	}
+
+	if err := c.guard(context.Background(), sendAction("1", "DOGE", "address"), succeed); !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("DOGE: %v, want %v", err, ErrUnknownCurrency)
+	}
+}
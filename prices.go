@@ -2,15 +2,70 @@ package coinbase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
 )
 
+// ErrInvalidCurrencyPair is returned when a currency pair is not in the BASE-QUOTE format, e.g. BTC-USD
+var ErrInvalidCurrencyPair = errors.New("coinbase: invalid currency pair, expected BASE-QUOTE e.g. BTC-USD")
+
+// currencyPairPattern matches currency pairs such as BTC-USD
+var currencyPairPattern = regexp.MustCompile(`^[A-Z0-9]{2,10}-[A-Z0-9]{2,10}$`)
+
+// ParseCurrencyPair parses and normalizes a currency pair such as "btc-usd".
+func ParseCurrencyPair(s string) (CurrencyPair, error) {
+	pair := CurrencyPair(strings.ToUpper(strings.TrimSpace(s)))
+
+	if err := pair.Validate(); err != nil {
+		return "", err
+	}
+
+	return pair, nil
+}
+
+// NewCurrencyPair returns the pair of base and quote currencies.
+func NewCurrencyPair(base string, quote string) (CurrencyPair, error) {
+	return ParseCurrencyPair(base + "-" + quote)
+}
+
+// Validate checks that the pair is in the BASE-QUOTE format.
+func (p CurrencyPair) Validate() error {
+	if !currencyPairPattern.MatchString(string(p)) {
+		return ErrInvalidCurrencyPair
+	}
+
+	return nil
+}
+
+// Base returns the base currency of the pair, e.g. BTC for BTC-USD.
+func (p CurrencyPair) Base() string {
+	return strings.SplitN(string(p), "-", 2)[0]
+}
+
+// Quote returns the quote currency of the pair, e.g. USD for BTC-USD.
+func (p CurrencyPair) Quote() string {
+	parts := strings.SplitN(string(p), "-", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[1]
+}
+
 // GetBuyPrice Get the total price to buy one bitcoin or ether.
 // Endpoint: GET /prices/:currency_pair/buy
-func (c *Client) GetBuyPrice(ctx context.Context, currencyPair string) (*Price, error) {
+func (c *Client) GetBuyPrice(ctx context.Context, currencyPair CurrencyPair) (*Price, error) {
 	priceResponse := &Price{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s", c.APIBase, currencyPair, "buy"), nil)
+	if err := currencyPair.Validate(); err != nil {
+		return priceResponse, err
+	}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "prices", currencyPair, "buy"), nil)
 	if err != nil {
 		return priceResponse, err
 	}
@@ -24,10 +79,14 @@ func (c *Client) GetBuyPrice(ctx context.Context, currencyPair string) (*Price,
 
 // GetSellPrice Get the total price to sell one bitcoin or ether.
 // Endpoint: GET /prices/:currency_pair/sell
-func (c *Client) GetSellPrice(ctx context.Context, currencyPair string) (*Price, error) {
+func (c *Client) GetSellPrice(ctx context.Context, currencyPair CurrencyPair) (*Price, error) {
 	priceResponse := &Price{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s", c.APIBase, currencyPair, "sell"), nil)
+	if err := currencyPair.Validate(); err != nil {
+		return priceResponse, err
+	}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "prices", currencyPair, "sell"), nil)
 	if err != nil {
 		return priceResponse, err
 	}
@@ -41,10 +100,25 @@ func (c *Client) GetSellPrice(ctx context.Context, currencyPair string) (*Price,
 
 // GetSpotPrice Get the current market price for bitcoin.
 // Endpoint: GET /prices/:currency_pair/spot
-func (c *Client) GetSpotPrice(ctx context.Context, currencyPair string) (*Price, error) {
+func (c *Client) GetSpotPrice(ctx context.Context, currencyPair CurrencyPair) (*Price, error) {
+	return c.getSpotPrice(ctx, currencyPair, time.Time{})
+}
+
+// GetHistoricSpotPrice Get the market price for bitcoin at date.
+// Endpoint: GET /prices/:currency_pair/spot?date=YYYY-MM-DD
+func (c *Client) GetHistoricSpotPrice(ctx context.Context, currencyPair CurrencyPair, date time.Time) (*Price, error) {
+	return c.getSpotPrice(ctx, currencyPair, date)
+}
+
+// getSpotPrice gets the spot price of the pair, the current one when date is zero
+func (c *Client) getSpotPrice(ctx context.Context, currencyPair CurrencyPair, date time.Time) (*Price, error) {
 	priceResponse := &Price{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s", c.APIBase, currencyPair, "spot"), nil)
+	if err := currencyPair.Validate(); err != nil {
+		return priceResponse, err
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withDate(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "prices", currencyPair, "spot"), date), nil)
 	if err != nil {
 		return priceResponse, err
 	}
@@ -55,3 +129,40 @@ func (c *Client) GetSpotPrice(ctx context.Context, currencyPair string) (*Price,
 
 	return priceResponse, nil
 }
+
+// ListSpotPrices Get the current market price of every currency against currency.
+// Endpoint: GET /prices/:currency/spot
+func (c *Client) ListSpotPrices(ctx context.Context, currency string) (*[]Price, error) {
+	return c.listSpotPrices(ctx, currency, time.Time{})
+}
+
+// ListHistoricSpotPrices Get the market price of every currency against currency at date.
+// Endpoint: GET /prices/:currency/spot?date=YYYY-MM-DD
+func (c *Client) ListHistoricSpotPrices(ctx context.Context, currency string, date time.Time) (*[]Price, error) {
+	return c.listSpotPrices(ctx, currency, date)
+}
+
+// listSpotPrices lists the spot prices against currency, the current ones when date is zero
+func (c *Client) listSpotPrices(ctx context.Context, currency string, date time.Time) (*[]Price, error) {
+	prices := &[]Price{}
+
+	req, err := c.NewRequest(ctx, "GET", withDate(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "prices", url.PathEscape(strings.ToUpper(currency)), "spot"), date), nil)
+	if err != nil {
+		return prices, err
+	}
+
+	if err = c.Send(req, prices); err != nil {
+		return prices, err
+	}
+
+	return prices, nil
+}
+
+// withDate appends the date query parameter, in YYYY-MM-DD format, when date is not zero
+func withDate(uri string, date time.Time) string {
+	if date.IsZero() {
+		return uri
+	}
+
+	return uri + "?" + url.Values{"date": {date.Format("2006-01-02")}}.Encode()
+}
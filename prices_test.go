@@ -0,0 +1,54 @@
+package coinbase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSpotPrices(t *testing.T) {
+	var uri string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		if r.URL.Path == "/prices/USD/spot" {
+			w.Write([]byte(`{"data":[{"base":"BTC","currency":"USD","amount":"1000.00"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"base":"BTC","currency":"USD","amount":"1000.00"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(testAPIKey, testAPISecret)
+	c.APIBase = server.URL
+
+	ctx := context.Background()
+	date := time.Date(2020, 1, 2, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{"current", func() error { _, err := c.GetSpotPrice(ctx, "BTC-USD"); return err }, "/prices/BTC-USD/spot"},
+		{"historic", func() error { _, err := c.GetHistoricSpotPrice(ctx, "BTC-USD", date); return err }, "/prices/BTC-USD/spot?date=2020-01-02"},
+		{"historic without date", func() error { _, err := c.GetHistoricSpotPrice(ctx, "BTC-USD", time.Time{}); return err }, "/prices/BTC-USD/spot"},
+		{"list", func() error { _, err := c.ListSpotPrices(ctx, "usd"); return err }, "/prices/USD/spot"},
+		{"list historic", func() error { _, err := c.ListHistoricSpotPrices(ctx, "USD", date); return err }, "/prices/USD/spot?date=2020-01-02"},
+	}
+
+	for _, test := range tests {
+		uri = ""
+		if err := test.call(); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+		if uri != test.want {
+			t.Errorf("%s: requested %s, want %s", test.name, uri, test.want)
+		}
+	}
+
+	if _, err := c.GetSpotPrice(ctx, "BTCUSD"); err != ErrInvalidCurrencyPair {
+		t.Errorf("invalid pair: %v, want %v", err, ErrInvalidCurrencyPair)
+	}
+}
@@ -0,0 +1,331 @@
+package qrcode
+
+// Penalty weights of the mask evaluation
+const (
+	penaltyRun    = 3
+	penaltyBlock  = 3
+	penaltyFinder = 40
+	penaltyRatio  = 10
+)
+
+// newCode returns a code of version with its function patterns drawn
+func newCode(version int, level Level) *Code {
+	size := version*4 + 17
+
+	c := &Code{
+		Version:    version,
+		Level:      level,
+		Size:       size,
+		modules:    make([][]bool, size),
+		isFunction: make([][]bool, size),
+	}
+
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.isFunction[i] = make([]bool, size)
+	}
+
+	c.drawFunctionPatterns()
+
+	return c
+}
+
+// setFunction sets a module which is not part of the data
+func (c *Code) setFunction(x int, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+// drawFunctionPatterns draws timing, finder and alignment patterns, and reserves the format and version areas
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	positions := alignmentPositions(c.Version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Corners are taken by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(x, y)
+		}
+	}
+
+	c.drawFormat(0)
+	c.drawVersion()
+}
+
+// drawFinder draws a finder pattern and its separator centered on x, y
+func (c *Code) drawFinder(x int, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= c.Size || yy >= c.Size {
+				continue
+			}
+			distance := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, distance != 2 && distance != 4)
+		}
+	}
+}
+
+// drawAlignment draws an alignment pattern centered on x, y
+func (c *Code) drawAlignment(x int, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// alignmentPositions returns the coordinates of the alignment pattern centers, on both axes
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	count := version/7 + 2
+	step := (version*8 + count*3 + 5) / (count*4 - 4) * 2
+
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, position := count-1, version*4+10; i >= 1; i, position = i-1, position-step {
+		positions[i] = position
+	}
+
+	return positions
+}
+
+// drawFormat draws both copies of the format information, level and mask protected by a BCH code
+func (c *Code) drawFormat(mask int) {
+	data := formatLevelBits[c.Level]<<3 | mask
+
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>uint(i))&1 == 1 }
+
+	// Around the top left finder
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	// Split between the other two finders
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(i))
+	}
+
+	// Always dark
+	c.setFunction(8, c.Size-8, true)
+}
+
+// drawVersion draws both copies of the version information, from version 7
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+
+	remainder := c.Version
+	for i := 0; i < 12; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1f25)
+	}
+	bits := c.Version<<12 | remainder
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>uint(i))&1 == 1
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the zigzag order, two columns at a time from the bottom right
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		// The vertical timing pattern is skipped
+		if right == 6 {
+			right = 5
+		}
+
+		upward := (right+1)&2 == 0
+
+		for vertical := 0; vertical < c.Size; vertical++ {
+			y := vertical
+			if upward {
+				y = c.Size - 1 - vertical
+			}
+
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.isFunction[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				c.modules[y][x] = (codewords[i/8]>>uint(7-i%8))&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// masked reports whether mask inverts the module at x, y
+func masked(mask int, x int, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// applyMask inverts the data modules selected by mask, applying it twice restores them
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.isFunction[y][x] && masked(mask, x, y) {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask with the lowest penalty
+func (c *Code) applyBestMask() {
+	best, bestPenalty := 0, -1
+
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+
+		c.applyMask(mask)
+	}
+
+	c.applyMask(best)
+	c.drawFormat(best)
+}
+
+// penalty scores the code: long runs, 2x2 blocks, finder-like patterns and an unbalanced share of dark modules
+func (c *Code) penalty() int {
+	penalty := 0
+
+	for i := 0; i < c.Size; i++ {
+		penalty += c.linePenalty(func(j int) bool { return c.modules[i][j] })
+		penalty += c.linePenalty(func(j int) bool { return c.modules[j][i] })
+	}
+
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+
+			if x+1 < c.Size && y+1 < c.Size {
+				color := c.modules[y][x]
+				if c.modules[y][x+1] == color && c.modules[y+1][x] == color && c.modules[y+1][x+1] == color {
+					penalty += penaltyBlock
+				}
+			}
+		}
+	}
+
+	total := c.Size * c.Size
+	penalty += abs(dark*2-total) * 10 / total * penaltyRatio
+
+	return penalty
+}
+
+// finderLike are the patterns 1:1:3:1:1 followed or preceded by four light modules
+var finderLike = [2][11]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// linePenalty scores a row or a column, module returns its j-th module.
+// The quiet zone is light, finder-like patterns are also found against the edges of the code.
+func (c *Code) linePenalty(module func(j int) bool) int {
+	penalty := 0
+
+	run := 1
+	for j := 1; j <= c.Size; j++ {
+		if j < c.Size && module(j) == module(j-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			penalty += penaltyRun + run - 5
+		}
+		run = 1
+	}
+
+	dark := func(j int) bool { return j >= 0 && j < c.Size && module(j) }
+
+	for j := -4; j+7 <= c.Size; j++ {
+		for _, pattern := range finderLike {
+			found := true
+			for k := 0; k < 11 && found; k++ {
+				found = dark(j+k) == pattern[k]
+			}
+			if found {
+				penalty += penaltyFinder
+			}
+		}
+	}
+
+	return penalty
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// max returns the largest of a and b
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
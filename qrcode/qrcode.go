@@ -0,0 +1,301 @@
+// Package qrcode encodes text, such as payment URIs and addresses, into QR codes (ISO/IEC 18004) and renders them
+// as PNG, SVG or Unicode text. It has no dependencies and makes no network calls.
+package qrcode
+
+import (
+	"errors"
+	"strings"
+)
+
+// Level is the error correction level, higher levels survive more damage but need larger codes
+type Level int
+
+// Error correction levels, with the share of codewords which can be restored
+const (
+	Low      Level = iota // 7%
+	Medium                // 15%
+	Quartile              // 25%
+	High                  // 30%
+)
+
+// Version limits
+const (
+	minVersion = 1
+	maxVersion = 40
+)
+
+// ErrTooLong is returned when the text does not fit in the largest QR code at the requested level
+var ErrTooLong = errors.New("qrcode: text too long")
+
+// ErrInvalidLevel is returned for an unknown error correction level
+var ErrInvalidLevel = errors.New("qrcode: invalid error correction level")
+
+// eccCodewordsPerBlock is the number of error correction codewords of each block, by level and version
+var eccCodewordsPerBlock = [4][maxVersion + 1]int{
+	{0, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{0, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{0, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// errorCorrectionBlocks is the number of blocks the codewords are split in, by level and version
+var errorCorrectionBlocks = [4][maxVersion + 1]int{
+	{0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{0, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{0, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// mode is an encoding mode of the data, with the length of its character count indicator
+// for versions 1-9, 10-26 and 27-40
+type mode struct {
+	indicator int
+	countBits [3]int
+}
+
+// Encoding modes
+var (
+	modeNumeric      = mode{indicator: 0x1, countBits: [3]int{10, 12, 14}}
+	modeAlphanumeric = mode{indicator: 0x2, countBits: [3]int{9, 11, 13}}
+	modeByte         = mode{indicator: 0x4, countBits: [3]int{8, 16, 16}}
+)
+
+// alphanumericChars are the characters of the alphanumeric mode, by value
+const alphanumericChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// formatLevelBits are the bits identifying each level in the format information
+var formatLevelBits = [4]int{1, 0, 3, 2}
+
+// Code is an encoded QR code, a square of dark and light modules
+type Code struct {
+	Version int   // From 1 (21x21 modules) to 40 (177x177 modules)
+	Level   Level // Error correction level
+	Size    int   // Number of modules on each side, without the quiet zone
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Encode encodes text into the smallest QR code holding it at the error correction level.
+// The most compact mode fitting the whole text is used: numeric for digits only, alphanumeric for digits,
+// uppercase letters and " $%*+-./:", byte otherwise. Uppercase URIs without parameters, e.g. BITCOIN:BC1Q..., are alphanumeric.
+func Encode(text string, level Level) (*Code, error) {
+	if level < Low || level > High {
+		return nil, ErrInvalidLevel
+	}
+
+	data := []byte(text)
+	m := modeOf(data)
+
+	version := minVersion
+	for ; version <= maxVersion; version++ {
+		if dataBits(m, version, len(data)) <= dataCodewords(version, level)*8 {
+			break
+		}
+	}
+
+	if version > maxVersion {
+		return nil, ErrTooLong
+	}
+
+	code := newCode(version, level)
+	code.drawCodewords(code.addErrorCorrection(encodeData(m, data, version, level)))
+	code.applyBestMask()
+
+	return code, nil
+}
+
+// Dark reports whether the module at column x and row y is dark, modules outside the code are light
+func (c *Code) Dark(x int, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+
+	return c.modules[y][x]
+}
+
+// modeOf returns the most compact mode able to encode all of data
+func modeOf(data []byte) mode {
+	m := modeNumeric
+
+	for _, b := range data {
+		switch {
+		case b >= '0' && b <= '9':
+		case strings.IndexByte(alphanumericChars, b) >= 0:
+			m = modeAlphanumeric
+		default:
+			return modeByte
+		}
+	}
+
+	return m
+}
+
+// countBits returns the length of the character count indicator of mode m
+func countBits(m mode, version int) int {
+	switch {
+	case version <= 9:
+		return m.countBits[0]
+	case version <= 26:
+		return m.countBits[1]
+	}
+
+	return m.countBits[2]
+}
+
+// dataBits returns the number of bits needed to encode n characters in mode m
+func dataBits(m mode, version int, n int) int {
+	if n >= 1<<uint(countBits(m, version)) {
+		return 1 << 30
+	}
+
+	bits := 4 + countBits(m, version)
+
+	switch m.indicator {
+	case modeNumeric.indicator:
+		bits += n/3*10 + [3]int{0, 4, 7}[n%3]
+	case modeAlphanumeric.indicator:
+		bits += n/2*11 + n%2*6
+	default:
+		bits += n * 8
+	}
+
+	return bits
+}
+
+// rawDataModules returns the number of modules available for data and error correction, remainder bits included
+func rawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+
+	if version >= 2 {
+		alignments := version/7 + 2
+		result -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+
+	return result
+}
+
+// dataCodewords returns the number of data codewords of a version at a level
+func dataCodewords(version int, level Level) int {
+	return rawDataModules(version)/8 - eccCodewordsPerBlock[level][version]*errorCorrectionBlocks[level][version]
+}
+
+// encodeData returns the data codewords: a segment of mode m, terminator and padding
+func encodeData(m mode, data []byte, version int, level Level) []byte {
+	capacity := dataCodewords(version, level) * 8
+
+	bits := &bitBuffer{}
+	bits.append(m.indicator, 4)
+	bits.append(len(data), countBits(m, version))
+
+	switch m.indicator {
+	case modeNumeric.indicator:
+		// Groups of three digits in 10 bits, a last group of one or two digits in 4 or 7 bits
+		for i := 0; i < len(data); i += 3 {
+			group := data[i:]
+			if len(group) > 3 {
+				group = group[:3]
+			}
+
+			value := 0
+			for _, digit := range group {
+				value = value*10 + int(digit-'0')
+			}
+			bits.append(value, [4]int{0, 4, 7, 10}[len(group)])
+		}
+	case modeAlphanumeric.indicator:
+		// Pairs of characters in 11 bits, a last single one in 6 bits
+		for i := 0; i < len(data); i += 2 {
+			value := strings.IndexByte(alphanumericChars, data[i])
+			if i+1 == len(data) {
+				bits.append(value, 6)
+				break
+			}
+			bits.append(value*45+strings.IndexByte(alphanumericChars, data[i+1]), 11)
+		}
+	default:
+		for _, b := range data {
+			bits.append(int(b), 8)
+		}
+	}
+
+	// Terminator, up to 4 zero bits, then zeros to the byte boundary
+	terminator := capacity - bits.len
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-bits.len%8)%8)
+
+	// Alternating pad bytes up to the capacity
+	for pad := 0xec; bits.len < capacity; pad ^= 0xec ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	return bits.bytes
+}
+
+// bitBuffer is a sequence of bits, most significant first
+type bitBuffer struct {
+	bytes []byte
+	len   int
+}
+
+// append appends the n low bits of value
+func (b *bitBuffer) append(value int, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if b.len%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if (value>>uint(i))&1 == 1 {
+			b.bytes[b.len/8] |= 0x80 >> uint(b.len%8)
+		}
+		b.len++
+	}
+}
+
+// addErrorCorrection splits data in blocks, appends their error correction codewords and interleaves them
+func (c *Code) addErrorCorrection(data []byte) []byte {
+	numBlocks := errorCorrectionBlocks[c.Level][c.Version]
+	eccLen := eccCodewordsPerBlock[c.Level][c.Version]
+	rawCodewords := rawDataModules(c.Version) / 8
+
+	// Blocks are short or one codeword longer, short ones come first
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(eccLen)
+
+	blocks := make([][]byte, numBlocks)
+	for i, offset := 0, 0; i < numBlocks; i++ {
+		length := shortBlockLen - eccLen
+		if i >= numShortBlocks {
+			length++
+		}
+
+		block := data[offset : offset+length]
+		offset += length
+
+		ecc := reedSolomonRemainder(block, divisor)
+
+		// Short blocks get a placeholder so that every block has the same length
+		if i < numShortBlocks {
+			block = append(append([]byte{}, block...), 0)
+		}
+		blocks[i] = append(append([]byte{}, block...), ecc...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i < len(blocks[0]); i++ {
+		for j, block := range blocks {
+			if i != shortBlockLen-eccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+
+	return result
+}
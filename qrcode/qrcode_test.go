@@ -0,0 +1,247 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"image/png"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of testdata")
+
+// Texts of every mode, golden files are named after the mode and the level
+var modeTexts = []struct {
+	mode string
+	text string
+}{
+	{"numeric", "3141592653589793238462643383279502884197"},
+	{"alphanumeric", "BITCOIN:BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4"},
+	{"byte", "bitcoin:bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4?amount=0.01"},
+}
+
+var levelNames = [4]string{"L", "M", "Q", "H"}
+
+// golden compares data with the golden file name, or rewrites it with -update
+func golden(t *testing.T, name string, data []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("%s differs from the golden file:\n%s", name, data)
+	}
+}
+
+// text returns the modules of the code, # for dark and . for light
+func (c *Code) text() []byte {
+	buf := &bytes.Buffer{}
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.Dark(x, y) {
+				buf.WriteByte('#')
+			} else {
+				buf.WriteByte('.')
+			}
+		}
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
+
+// format decodes the level and mask of the format information around the top left finder
+func (c *Code) format() (Level, int) {
+	bits := 0
+	for i := 0; i <= 5; i++ {
+		bits |= b2i(c.Dark(8, i)) << uint(i)
+	}
+	bits |= b2i(c.Dark(8, 7)) << 6
+	bits |= b2i(c.Dark(8, 8)) << 7
+	bits |= b2i(c.Dark(7, 8)) << 8
+	for i := 9; i < 15; i++ {
+		bits |= b2i(c.Dark(14-i, 8)) << uint(i)
+	}
+	bits ^= 0x5412
+
+	level := Level(0)
+	for l, levelBits := range formatLevelBits {
+		if levelBits == bits>>13 {
+			level = Level(l)
+		}
+	}
+
+	return level, bits >> 10 & 7
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func TestModes(t *testing.T) {
+	tests := []struct {
+		text string
+		mode mode
+	}{
+		{"", modeNumeric},
+		{"0123456789", modeNumeric},
+		{"HELLO WORLD", modeAlphanumeric},
+		{"0 $%*+-./:AZ", modeAlphanumeric},
+		{"Hello", modeByte},
+		{"HELLO!", modeByte},
+		{"BITCOIN:BC1Q?AMOUNT=1", modeByte},
+		{"ÉTÉ", modeByte},
+	}
+
+	for _, test := range tests {
+		if m := modeOf([]byte(test.text)); m != test.mode {
+			t.Errorf("%q: mode %x, want %x", test.text, m.indicator, test.mode.indicator)
+		}
+	}
+}
+
+// TestVersion checks the capacity of every mode against the ISO/IEC 18004 tables, at the smallest and largest versions
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		alphabet string
+		level    Level
+		length   int
+		version  int
+	}{
+		{"0", Low, 41, 1},
+		{"0", Low, 42, 2},
+		{"A", Low, 25, 1},
+		{"A", Low, 26, 2},
+		{"a", Low, 17, 1},
+		{"a", Low, 18, 2},
+		{"0", High, 17, 1},
+		{"0", High, 18, 2},
+		{"A", High, 10, 1},
+		{"A", High, 11, 2},
+		{"a", High, 7, 1},
+		{"a", High, 8, 2},
+		{"0", Medium, 34, 1},
+		{"A", Quartile, 16, 1},
+		// Byte mode count indicator grows from 8 to 16 bits at version 10
+		{"a", Low, 230, 9},
+		{"a", Low, 231, 10},
+		{"a", Low, 271, 10},
+		{"0", Low, 7089, 40},
+		{"A", Low, 4296, 40},
+		{"a", Low, 2953, 40},
+		{"0", High, 3057, 40},
+		{"A", High, 1852, 40},
+		{"a", High, 1273, 40},
+	}
+
+	for _, test := range tests {
+		code, err := Encode(strings.Repeat(test.alphabet, test.length), test.level)
+		if err != nil {
+			t.Errorf("%d × %q at %s: %v", test.length, test.alphabet, levelNames[test.level], err)
+			continue
+		}
+		if code.Version != test.version || code.Size != test.version*4+17 {
+			t.Errorf("%d × %q at %s: version %d, size %d, want version %d", test.length, test.alphabet, levelNames[test.level], code.Version, code.Size, test.version)
+		}
+	}
+
+	for _, test := range tests {
+		if test.version != 40 {
+			continue
+		}
+		if _, err := Encode(strings.Repeat(test.alphabet, test.length+1), test.level); !errors.Is(err, ErrTooLong) {
+			t.Errorf("%d × %q at %s: %v, want %v", test.length+1, test.alphabet, levelNames[test.level], err, ErrTooLong)
+		}
+	}
+
+	if _, err := Encode("x", Level(4)); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("invalid level: %v, want %v", err, ErrInvalidLevel)
+	}
+}
+
+// TestGolden compares the modules of every mode at every level with golden files,
+// which were checked against an independent encoder, and the version and mask selected
+func TestGolden(t *testing.T) {
+	want := map[string][4][2]int{
+		"numeric":      {{1, 3}, {2, 0}, {2, 0}, {3, 1}},
+		"alphanumeric": {{3, 4}, {3, 3}, {4, 4}, {4, 1}},
+		"byte":         {{4, 2}, {4, 4}, {6, 7}, {7, 7}},
+	}
+
+	for _, m := range modeTexts {
+		for level := Low; level <= High; level++ {
+			code, err := Encode(m.text, level)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			formatLevel, mask := code.format()
+			if formatLevel != level {
+				t.Errorf("%s at %s: format level %s", m.mode, levelNames[level], levelNames[formatLevel])
+			}
+			if version := want[m.mode][level][0]; code.Version != version {
+				t.Errorf("%s at %s: version %d, want %d", m.mode, levelNames[level], code.Version, version)
+			}
+			if wantMask := want[m.mode][level][1]; mask != wantMask {
+				t.Errorf("%s at %s: mask %d, want %d", m.mode, levelNames[level], mask, wantMask)
+			}
+
+			golden(t, m.mode+"-"+levelNames[level]+".txt", code.text())
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	code, err := Encode(modeTexts[2].text, Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden(t, "byte-M.svg", []byte(code.SVG(264)))
+	golden(t, "byte-M-unicode.txt", []byte(code.Unicode(false)))
+	golden(t, "byte-M-unicode-inverted.txt", []byte(code.Unicode(true)))
+
+	// 33 modules and the quiet zones in 264 pixels: 6 pixels per module
+	data, err := code.PNG(264)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != 246 || bounds.Dy() != 246 {
+		t.Fatalf("PNG of %v, want 246x246", bounds)
+	}
+	for y := 0; y < 246; y++ {
+		for x := 0; x < 246; x++ {
+			r, _, _, _ := img.At(x, y).RGBA()
+			if dark := r == 0; dark != code.Dark(x/6-quietZone, y/6-quietZone) {
+				t.Fatalf("pixel %d,%d dark %v", x, y, dark)
+			}
+		}
+	}
+
+	// Images smaller than the code still have a pixel per module
+	if bounds := code.Image(10).Bounds(); bounds.Dx() != code.Size+2*quietZone {
+		t.Fatalf("minimum image of %v", bounds)
+	}
+}
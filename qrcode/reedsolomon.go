@@ -0,0 +1,49 @@
+package qrcode
+
+// gfMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x byte, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11d)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+
+	return byte(z)
+}
+
+// reedSolomonDivisor returns the generator polynomial of degree, product of (x - α^i) for i below degree.
+// Coefficients are from the highest to the lowest power, the leading 1 is omitted.
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		// Multiply by (x - root)
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords of data, the remainder of its division by divisor
+func reedSolomonRemainder(data []byte, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range divisor {
+			result[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+
+	return result
+}
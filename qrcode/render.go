@@ -0,0 +1,102 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// quietZone is the light margin around the code, in modules, required by scanners
+const quietZone = 4
+
+// scale returns the number of pixels per module for an image of about size pixels, at least 1
+func (c *Code) scale(size int) int {
+	scale := size / (c.Size + 2*quietZone)
+	if scale < 1 {
+		return 1
+	}
+
+	return scale
+}
+
+// Image renders the code, quiet zone included, in an image of at most size pixels per side.
+// Modules are whole pixels, so the image is smaller than size unless it is a multiple of the modules count.
+func (c *Code) Image(size int) image.Image {
+	scale := c.scale(size)
+	pixels := (c.Size + 2*quietZone) * scale
+
+	img := image.NewPaletted(image.Rect(0, 0, pixels, pixels), color.Palette{color.White, color.Black})
+
+	for y := 0; y < pixels; y++ {
+		for x := 0; x < pixels; x++ {
+			if c.Dark(x/scale-quietZone, y/scale-quietZone) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	return img
+}
+
+// PNG renders the code as a PNG image of at most size pixels per side.
+func (c *Code) PNG(size int) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	if err := png.Encode(buf, c.Image(size)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SVG renders the code as an SVG document of size pixels per side, it scales without loss.
+func (c *Code) SVG(size int) string {
+	modules := c.Size + 2*quietZone
+
+	path := &strings.Builder{}
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				fmt.Fprintf(path, "M%d,%dh1v1h-1z", x+quietZone, y+quietZone)
+			}
+		}
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">
+<rect width="100%%" height="100%%" fill="#ffffff"/>
+<path d="%s" fill="#000000"/>
+</svg>
+`, size, size, modules, modules, path.String())
+}
+
+// Unicode renders the code as text with half block characters, two rows of modules per line.
+// Dark modules are drawn with the foreground color, set invert for terminals with light text on a dark background.
+func (c *Code) Unicode(invert bool) string {
+	blocks := [4]string{" ", "▄", "▀", "█"}
+
+	text := &strings.Builder{}
+	for y := -quietZone; y < c.Size+quietZone; y += 2 {
+		for x := -quietZone; x < c.Size+quietZone; x++ {
+			top, bottom := c.Dark(x, y), c.Dark(x, y+1)
+			if invert {
+				top, bottom = !top, !bottom
+			}
+
+			i := 0
+			if top {
+				i |= 2
+			}
+			if bottom {
+				i |= 1
+			}
+			text.WriteString(blocks[i])
+		}
+		text.WriteString("\n")
+	}
+
+	return text.String()
+}
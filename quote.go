@@ -0,0 +1,291 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// DefaultQuoteTTL is how long a quote can be committed when QuoteOptions.TTL is not set
+const DefaultQuoteTTL = time.Minute
+
+// Quote errors
+var (
+	ErrQuoteExpired      = errors.New("coinbase: quote expired")
+	ErrQuoteCanceled     = errors.New("coinbase: quote canceled")
+	ErrQuoteCommitted    = errors.New("coinbase: quote already committed")
+	ErrSlippageExceeded  = errors.New("coinbase: price moved more than the allowed slippage")
+	ErrInvalidQuoteTrade = errors.New("coinbase: quote has no amount to price")
+)
+
+// Kinds of quote
+const (
+	QuoteKindBuy        = "buy"
+	QuoteKindSell       = "sell"
+	QuoteKindDeposit    = "deposit"
+	QuoteKindWithdrawal = "withdrawal"
+)
+
+// Quote is an uncommitted buy, sell, deposit or withdrawal, created with commit: false.
+// Review it and then Commit it before it expires, or Cancel it. The client Policy is checked on Commit.
+type Quote struct {
+	Kind      string   // QuoteKindBuy, QuoteKindSell, QuoteKindDeposit or QuoteKindWithdrawal
+	Trade     Trade    // The uncommitted *Buy, *Sell, *Deposit or *Withdrawal
+	Price     *Decimal // Price of one unit, excluding fees. Only for buys and sells
+	ExpiresAt time.Time
+
+	clock       Clock
+	maxSlippage *Decimal
+	fresh       func(ctx context.Context) (Trade, error)
+	commit      func(ctx context.Context) (Trade, error)
+
+	mu        sync.Mutex
+	committed bool
+	canceled  bool
+}
+
+// ID returns the ID of the uncommitted resource.
+func (q *Quote) ID() string { return q.Trade.GetID() }
+
+// Amount returns the amount bought, sold, deposited or withdrawn.
+func (q *Quote) Amount() Money { return q.Trade.GetAmount() }
+
+// Fee returns the fees.
+func (q *Quote) Fee() Money { return q.Trade.GetFee() }
+
+// Total returns the total, including fees.
+func (q *Quote) Total() Money { return q.Trade.GetTotal() }
+
+// Expired reports whether the quote can no longer be committed, according to the Clock of the client.
+func (q *Quote) Expired() bool {
+	return !q.clock.Now().Before(q.ExpiresAt)
+}
+
+// Cancel discards the quote, it can no longer be committed.
+// Uncommitted resources are never executed, the server discards them on its own.
+func (q *Quote) Cancel() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.canceled = true
+}
+
+// Commit executes the quote. It fails if the quote expired, was canceled or already committed and,
+// when a maximum slippage is configured, if the price of a fresh quote moved beyond it.
+func (q *Quote) Commit(ctx context.Context) (Trade, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	switch {
+	case q.canceled:
+		return nil, ErrQuoteCanceled
+	case q.committed:
+		return nil, ErrQuoteCommitted
+	case q.Expired():
+		return nil, ErrQuoteExpired
+	}
+
+	if err := q.checkSlippage(ctx); err != nil {
+		return nil, err
+	}
+
+	trade, err := q.commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q.committed = true
+
+	return trade, nil
+}
+
+// checkSlippage compares the quoted price with the one of a fresh quote
+func (q *Quote) checkSlippage(ctx context.Context) error {
+	if q.maxSlippage == nil || q.fresh == nil {
+		return nil
+	}
+
+	trade, err := q.fresh(ctx)
+	if err != nil {
+		return err
+	}
+
+	price, err := unitPrice(trade)
+	if err != nil {
+		return err
+	}
+
+	// Relative change: |fresh - quoted| / quoted
+	change := new(big.Rat).Sub(price.Rat(), q.Price.Rat())
+	change.Abs(change)
+	if q.Price.Sign() != 0 {
+		change.Quo(change, q.Price.Rat())
+	}
+
+	if change.Cmp(q.maxSlippage.Rat()) > 0 {
+		return fmt.Errorf("%w: quoted %s, now %s", ErrSlippageExceeded, q.Price, price)
+	}
+
+	return nil
+}
+
+// unitPrice returns the price of one unit of a buy or sell, excluding fees
+func unitPrice(trade Trade) (*Decimal, error) {
+	amount := trade.GetAmount()
+	if amount.IsZero() {
+		return nil, ErrInvalidQuoteTrade
+	}
+
+	subTotal := trade.GetSubTotal()
+
+	return subTotal.Amount.Quo(amount.Amount, 8)
+}
+
+// newQuote builds a Quote for the uncommitted trade
+func newQuote(clock Clock, kind string, trade Trade, opts *QuoteOptions, fresh, commit func(ctx context.Context) (Trade, error)) (*Quote, error) {
+	ttl := DefaultQuoteTTL
+	if opts != nil && opts.TTL > 0 {
+		ttl = opts.TTL
+	}
+
+	quote := &Quote{
+		Kind:      kind,
+		Trade:     trade,
+		ExpiresAt: clock.Now().Add(ttl),
+		clock:     clock,
+		commit:    commit,
+	}
+
+	if fresh != nil {
+		price, err := unitPrice(trade)
+		if err != nil {
+			return nil, err
+		}
+
+		quote.Price = price
+		quote.fresh = fresh
+
+		if opts != nil {
+			quote.maxSlippage = opts.MaxSlippage
+		}
+	}
+
+	return quote, nil
+}
+
+// QuoteBuy places an uncommitted buy and returns it as a Quote, buyData.Commit and buyData.Quote are ignored.
+func (c *Client) QuoteBuy(ctx context.Context, accountID string, buyData PlaceBuy, opts *QuoteOptions) (*Quote, error) {
+	buyData.Quote = false
+
+	buy := &Buy{}
+	if err := c.postUncommitted(ctx, accountID, "buys", buyData, buy); err != nil {
+		return nil, err
+	}
+
+	fresh := func(ctx context.Context) (Trade, error) {
+		buyData.Quote = true
+		return c.PlaceBuy(ctx, accountID, buyData)
+	}
+
+	commit := func(ctx context.Context) (trade Trade, err error) {
+		err = c.guard(ctx, buyAction(accountID, buyData), func() error {
+			trade, err = c.CommitBuy(ctx, accountID, buy.ID)
+			return err
+		})
+		return trade, err
+	}
+
+	return newQuote(c.localClock(), QuoteKindBuy, buy, opts, fresh, commit)
+}
+
+// QuoteSell places an uncommitted sell and returns it as a Quote, sellData.Commit and sellData.Quote are ignored.
+func (c *Client) QuoteSell(ctx context.Context, accountID string, sellData PlaceSell, opts *QuoteOptions) (*Quote, error) {
+	sellData.Quote = false
+
+	sell := &Sell{}
+	if err := c.postUncommitted(ctx, accountID, "sells", sellData, sell); err != nil {
+		return nil, err
+	}
+
+	fresh := func(ctx context.Context) (Trade, error) {
+		sellData.Quote = true
+		return c.PlaceSell(ctx, accountID, sellData)
+	}
+
+	commit := func(ctx context.Context) (trade Trade, err error) {
+		err = c.guard(ctx, sellAction(accountID, sellData), func() error {
+			trade, err = c.CommitSell(ctx, accountID, sell.ID)
+			return err
+		})
+		return trade, err
+	}
+
+	return newQuote(c.localClock(), QuoteKindSell, sell, opts, fresh, commit)
+}
+
+// QuoteDeposit creates an uncommitted deposit and returns it as a Quote, depositData.Commit is ignored.
+// Slippage does not apply to deposits.
+func (c *Client) QuoteDeposit(ctx context.Context, accountID string, depositData DepositFunds, opts *QuoteOptions) (*Quote, error) {
+	deposit := &Deposit{}
+	if err := c.postUncommitted(ctx, accountID, "deposits", depositData, deposit); err != nil {
+		return nil, err
+	}
+
+	commit := func(ctx context.Context) (trade Trade, err error) {
+		action := PolicyAction{Operation: TransactionTypeFiatDeposit, AccountID: accountID, Amount: Money{Amount: depositData.Amount, Currency: depositData.Currency}}
+		err = c.guard(ctx, action, func() error {
+			trade, err = c.CommitDeposit(ctx, accountID, deposit.ID)
+			return err
+		})
+		return trade, err
+	}
+
+	return newQuote(c.localClock(), QuoteKindDeposit, deposit, opts, nil, commit)
+}
+
+// QuoteWithdrawal creates an uncommitted withdrawal and returns it as a Quote.
+// Slippage does not apply to withdrawals.
+func (c *Client) QuoteWithdrawal(ctx context.Context, accountID string, withdrawData Withdraw, opts *QuoteOptions) (*Quote, error) {
+	withdrawal := &Withdrawal{}
+	if err := c.postUncommitted(ctx, accountID, "withdrawals", withdrawData, withdrawal); err != nil {
+		return nil, err
+	}
+
+	commit := func(ctx context.Context) (trade Trade, err error) {
+		action := PolicyAction{Operation: TransactionTypeFiatWithdrawal, AccountID: accountID, Amount: Money{Amount: withdrawData.Amount, Currency: withdrawData.Currency}}
+		err = c.guard(ctx, action, func() error {
+			trade, err = c.CommitWithdrawal(ctx, accountID, withdrawal.ID)
+			return err
+		})
+		return trade, err
+	}
+
+	return newQuote(c.localClock(), QuoteKindWithdrawal, withdrawal, opts, nil, commit)
+}
+
+// postUncommitted creates a resource of an account with commit: false
+// Endpoint: POST /accounts/:account_id/:resource
+func (c *Client) postUncommitted(ctx context.Context, accountID string, resource string, payload interface{}, v interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	// commit is sent explicitly, the API commits when it is omitted
+	fields := map[string]json.RawMessage{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	fields["commit"] = json.RawMessage("false")
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, resource), fields)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, v)
+}
@@ -0,0 +1,166 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newQuoteClient(server *tradeServer) (*Client, *fakeClock) {
+	clock := newFakeClock(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC))
+
+	c := server.client()
+	c.Clock = clock
+
+	return c, clock
+}
+
+func quoteBuy(t *testing.T, c *Client, opts *QuoteOptions) *Quote {
+	t.Helper()
+
+	quote, err := c.QuoteBuy(context.Background(), "a1", PlaceBuy{Amount: MustParseDecimal("0.001"), Currency: "BTC", Commit: Bool(true)}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return quote
+}
+
+func TestQuoteCommit(t *testing.T) {
+	server := newTradeServer(t, "50000")
+	defer server.Close()
+	c, _ := newQuoteClient(server)
+
+	quote := quoteBuy(t, c, nil)
+	if quote.Price.String() != "50000.00000000" || quote.ID() != "b1" {
+		t.Fatalf("quote price %s, ID %s", quote.Price, quote.ID())
+	}
+
+	// Quoted uncommitted, whatever Commit was set to
+	if commit, ok := server.placed[0]["commit"]; !ok || commit != false {
+		t.Fatalf("quote placed with commit %v", commit)
+	}
+
+	trade, err := quote.Commit(context.Background())
+	if err != nil || !trade.(*Buy).Committed {
+		t.Fatalf("commit = %+v, %v", trade, err)
+	}
+
+	if _, err = quote.Commit(context.Background()); !errors.Is(err, ErrQuoteCommitted) {
+		t.Fatalf("second commit: %v, want %v", err, ErrQuoteCommitted)
+	}
+	if commits := server.committed(); len(commits) != 1 || commits[0] != "b1" {
+		t.Fatalf("commits = %v, want [b1]", commits)
+	}
+}
+
+func TestQuoteExpiry(t *testing.T) {
+	server := newTradeServer(t, "50000")
+	defer server.Close()
+	c, clock := newQuoteClient(server)
+
+	quote := quoteBuy(t, c, &QuoteOptions{TTL: 30 * time.Second})
+	if !quote.ExpiresAt.Equal(clock.Now().Add(30 * time.Second)) {
+		t.Fatalf("expires at %s", quote.ExpiresAt)
+	}
+
+	clock.Advance(29 * time.Second)
+	if quote.Expired() {
+		t.Fatalf("expired before its TTL")
+	}
+
+	clock.Advance(time.Second)
+	if !quote.Expired() {
+		t.Fatalf("not expired after its TTL")
+	}
+	if _, err := quote.Commit(context.Background()); !errors.Is(err, ErrQuoteExpired) {
+		t.Fatalf("commit after expiry: %v, want %v", err, ErrQuoteExpired)
+	}
+	if commits := server.committed(); len(commits) != 0 {
+		t.Fatalf("expired quote committed: %v", commits)
+	}
+}
+
+func TestQuoteCancel(t *testing.T) {
+	server := newTradeServer(t, "50000")
+	defer server.Close()
+	c, _ := newQuoteClient(server)
+
+	quote := quoteBuy(t, c, nil)
+	quote.Cancel()
+
+	if _, err := quote.Commit(context.Background()); !errors.Is(err, ErrQuoteCanceled) {
+		t.Fatalf("commit after cancel: %v, want %v", err, ErrQuoteCanceled)
+	}
+	if commits := server.committed(); len(commits) != 0 {
+		t.Fatalf("canceled quote committed: %v", commits)
+	}
+}
+
+func TestQuoteSlippage(t *testing.T) {
+	server := newTradeServer(t, "50000")
+	defer server.Close()
+	c, _ := newQuoteClient(server)
+
+	quote := quoteBuy(t, c, &QuoteOptions{MaxSlippage: MustParseDecimal("0.01")})
+
+	// 1.2% above the quoted price
+	server.setPrice("50600")
+	if _, err := quote.Commit(context.Background()); !errors.Is(err, ErrSlippageExceeded) {
+		t.Fatalf("commit beyond the slippage: %v, want %v", err, ErrSlippageExceeded)
+	}
+
+	// The fresh quote is only a quote
+	if fresh := server.placed[1]; fresh["quote"] != true {
+		t.Fatalf("fresh quote placed as %v", fresh)
+	}
+
+	// 0.8% below, the bound applies both ways but is not exceeded
+	server.setPrice("49600")
+	if _, err := quote.Commit(context.Background()); err != nil {
+		t.Fatalf("commit within the slippage: %v", err)
+	}
+	if commits := server.committed(); len(commits) != 1 || commits[0] != "b1" {
+		t.Fatalf("commits = %v, want [b1]", commits)
+	}
+}
+
+func TestQuotePolicy(t *testing.T) {
+	server := newTradeServer(t, "50000")
+	defer server.Close()
+	c, _ := newQuoteClient(server)
+	c.Policy = &Policy{BlockedCurrencies: []string{"BTC"}}
+
+	// Quoting moves no money
+	quote := quoteBuy(t, c, nil)
+
+	if _, err := quote.Commit(context.Background()); policyRule(err) != PolicyRuleCurrency {
+		t.Fatalf("commit of a blocked currency: %v, want a %s violation", err, PolicyRuleCurrency)
+	}
+	if commits := server.committed(); len(commits) != 0 {
+		t.Fatalf("blocked quote committed: %v", commits)
+	}
+}
+
+func TestPlaceBuyCommit(t *testing.T) {
+	server := newTradeServer(t, "50000")
+	defer server.Close()
+	c := server.client()
+
+	for _, commit := range []*bool{nil, Bool(false), Bool(true)} {
+		if _, err := c.PlaceBuy(context.Background(), "a1", PlaceBuy{Amount: MustParseDecimal("0.001"), Currency: "BTC", Commit: commit}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, sent := server.placed[0]["commit"]; sent {
+		t.Errorf("nil Commit sent as %v", server.placed[0]["commit"])
+	}
+	if commit, sent := server.placed[1]["commit"]; !sent || commit != false {
+		t.Errorf("Commit false sent as %v", commit)
+	}
+	if commit := server.placed[2]["commit"]; commit != true {
+		t.Errorf("Commit true sent as %v", commit)
+	}
+}
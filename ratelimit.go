@@ -0,0 +1,143 @@
+package coinbase
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Coinbase API quotas, 10,000 requests per hour both for API keys and for public endpoints
+const (
+	DefaultAuthRate   = 10000.0 / 3600
+	DefaultPublicRate = 10000.0 / 3600
+	DefaultBurst      = 10
+)
+
+// RateLimiter is a token bucket limiter safe for concurrent use.
+// It also adapts to the server, pausing every request when a 429 or an exhausted quota is reported.
+type RateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rate requests per second, with bursts of at most burst requests.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// SetRateLimit enables client-side throttling with the default Coinbase quotas,
+// using separate buckets for authenticated and public endpoints.
+func (c *Client) SetRateLimit() {
+	c.AuthLimiter = NewRateLimiter(DefaultAuthRate, DefaultBurst)
+	c.PublicLimiter = NewRateLimiter(DefaultPublicRate, DefaultBurst)
+}
+
+// limiter returns the bucket used by authenticated or public requests
+func (c *Client) limiter(auth bool) *RateLimiter {
+	if auth {
+		return c.AuthLimiter
+	}
+
+	return c.PublicLimiter
+}
+
+// Wait blocks until a request is allowed or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		delay := l.reserve(time.Now())
+		if delay <= 0 {
+			return nil
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if available, otherwise it returns how long to wait before trying again
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+		l.tokens += elapsed * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// observe adapts the limiter to the rate limit reported by the server
+func (l *RateLimiter) observe(resp *http.Response) {
+	if l == nil || resp == nil {
+		return
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		delay, ok := retryAfter(resp)
+		if !ok {
+			delay = time.Second
+		}
+		l.pause(delay)
+		return
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining > 0 {
+		return
+	}
+
+	if delay, ok := rateLimitReset(resp); ok {
+		l.pause(delay)
+	}
+}
+
+// pause stops every request for delay, tokens are drained so that the bucket refills from empty
+func (l *RateLimiter) pause(delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(delay)
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+
+	l.tokens = 0
+	l.last = l.pausedUntil
+}
@@ -0,0 +1,78 @@
+package coinbase
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	l := NewRateLimiter(2, 2)
+	now := l.last
+
+	// The burst is available at once
+	for i := 0; i < 2; i++ {
+		if delay := l.reserve(now); delay != 0 {
+			t.Fatalf("request %d of the burst delayed by %s", i, delay)
+		}
+	}
+
+	if delay := l.reserve(now); delay != 500*time.Millisecond {
+		t.Fatalf("delay after the burst = %s, want 500ms", delay)
+	}
+
+	now = now.Add(250 * time.Millisecond)
+	if delay := l.reserve(now); delay != 250*time.Millisecond {
+		t.Fatalf("delay after 250ms = %s, want 250ms", delay)
+	}
+
+	now = now.Add(250 * time.Millisecond)
+	if delay := l.reserve(now); delay != 0 {
+		t.Fatalf("delay after refill = %s", delay)
+	}
+
+	// Tokens do not accumulate beyond the burst
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		l.reserve(now)
+	}
+	if delay := l.reserve(now); delay == 0 {
+		t.Fatalf("more than the burst allowed after an idle hour")
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	reset := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+
+	tests := []struct {
+		resp     *http.Response
+		min, max time.Duration
+	}{
+		{response(http.StatusTooManyRequests, map[string]string{"Retry-After": "5"}), 4 * time.Second, 5 * time.Second},
+		{response(http.StatusTooManyRequests, nil), 900 * time.Millisecond, time.Second},
+		{response(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset}), 58 * time.Second, time.Minute},
+		{response(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "10", "X-RateLimit-Reset": reset}), 0, 0},
+		{response(http.StatusServiceUnavailable, map[string]string{"X-RateLimit-Reset": reset}), 0, 0},
+	}
+
+	for i, test := range tests {
+		l := NewRateLimiter(100, 10)
+		l.observe(test.resp)
+
+		if delay := l.reserve(time.Now()); delay < test.min || delay > test.max {
+			t.Errorf("test %d: paused for %s, want [%s, %s]", i, delay, test.min, test.max)
+		}
+	}
+
+	// A shorter pause does not shorten the current one
+	l := NewRateLimiter(100, 10)
+	l.observe(response(http.StatusTooManyRequests, map[string]string{"Retry-After": "10"}))
+	l.observe(response(http.StatusTooManyRequests, map[string]string{"Retry-After": "1"}))
+	if delay := l.reserve(time.Now()); delay < 9*time.Second {
+		t.Fatalf("pause shortened to %s", delay)
+	}
+
+	var nilLimiter *RateLimiter
+	nilLimiter.observe(response(http.StatusTooManyRequests, nil))
+}
@@ -0,0 +1,124 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownResource is returned when resolving a reference to an unsupported resource type
+var ErrUnknownResource = errors.New("coinbase: unknown resource type")
+
+// ResourceCache caches resources fetched by Client.Resolve, keyed by resource path.
+// It is safe for concurrent use, the zero value is an empty cache.
+type ResourceCache struct {
+	mu        sync.RWMutex
+	resources map[string]interface{}
+}
+
+// NewResourceCache returns an empty ResourceCache.
+func NewResourceCache() *ResourceCache {
+	return &ResourceCache{resources: map[string]interface{}{}}
+}
+
+// Clear removes every cached resource.
+func (rc *ResourceCache) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.resources = map[string]interface{}{}
+}
+
+// get returns the resource cached for path
+func (rc *ResourceCache) get(path string) (interface{}, bool) {
+	if rc == nil {
+		return nil, false
+	}
+
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	resource, ok := rc.resources[path]
+
+	return resource, ok
+}
+
+// set caches resource for path
+func (rc *ResourceCache) set(path string, resource interface{}) {
+	if rc == nil {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.resources == nil {
+		rc.resources = map[string]interface{}{}
+	}
+
+	rc.resources[path] = resource
+}
+
+// newResource returns an empty value of the given resource type
+func newResource(resource string) (interface{}, bool) {
+	switch resource {
+	case "account":
+		return &Account{}, true
+	case "address":
+		return &Address{}, true
+	case "buy":
+		return &Buy{}, true
+	case "deposit":
+		return &Deposit{}, true
+	case "payment_method":
+		return &PaymentMethod{}, true
+	case "sell":
+		return &Sell{}, true
+	case "transaction":
+		return &Transaction{}, true
+	case "user":
+		return &User{}, true
+	case "withdrawal":
+		return &Withdrawal{}, true
+	}
+
+	return nil, false
+}
+
+// Resolve fetches the resource ref points at through its resource path. The returned value is a pointer
+// to the type matching ref.Resource: *Account, *Address, *Buy, *Deposit, *PaymentMethod, *Sell,
+// *Transaction, *User or *Withdrawal. When ResourceCache is set every resource path is fetched only once,
+// cached values are shared and must not be modified.
+func (c *Client) Resolve(ctx context.Context, ref ResourceRef) (interface{}, error) {
+	if ref.ResourcePath == "" {
+		return nil, fmt.Errorf("coinbase: reference to %s %s has no resource path", ref.Resource, ref.ID)
+	}
+
+	if resource, ok := c.ResourceCache.get(ref.ResourcePath); ok {
+		return resource, nil
+	}
+
+	resource, ok := newResource(ref.Resource)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownResource, ref.Resource)
+	}
+
+	uri, err := c.resolveURI(ref.ResourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(ctx, "GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.SendWithAuth(req, resource); err != nil {
+		return nil, err
+	}
+
+	c.ResourceCache.set(ref.ResourcePath, resource)
+
+	return resource, nil
+}
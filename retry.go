@@ -0,0 +1,183 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// idempotentKey marks a request context as safe to be retried
+type idempotentKey struct{}
+
+// DefaultRetryPolicy returns a RetryPolicy with sensible defaults: up to 4 attempts,
+// starting from a 500ms delay and never waiting more than 30 seconds between attempts.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// withIdempotency marks the context of requests carrying an idempotency key,
+// such as SendMoney with Idem, so that they can be retried safely.
+func withIdempotency(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), idempotentKey{}, true))
+}
+
+// retry reports whether the request failed with err should be attempted again and how long to wait before.
+func (p *RetryPolicy) retry(req *http.Request, attempt int, err error) (time.Duration, bool) {
+	if p == nil || err == nil || attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	// The request succeeded, sending it again would not fix the body
+	if errors.Is(err, ErrInvalidResponse) {
+		return 0, false
+	}
+
+	if req.Context().Err() != nil || !p.idempotent(req) {
+		return 0, false
+	}
+
+	var resp *http.Response
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		resp = errResp.Response
+		if resp == nil || !retryableStatus(resp.StatusCode) {
+			return 0, false
+		}
+	}
+
+	if delay, ok := retryAfter(resp); ok {
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+		return delay, true
+	}
+
+	return p.backoff(attempt), true
+}
+
+// idempotent reports whether the request can be sent more than once without side effects
+func (p *RetryPolicy) idempotent(req *http.Request) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+
+	if marked, _ := req.Context().Value(idempotentKey{}).(bool); marked {
+		return true
+	}
+
+	return p.RetryNonIdempotent
+}
+
+// backoff computes the exponential delay for attempt, with jitter applied to its upper half
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryableStatus reports whether the status code represents a transient failure
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// retryAfter extracts the delay requested by the server through the Retry-After header,
+// either as a number of seconds or as an HTTP date. Without it, a 429 waits until X-RateLimit-Reset:
+// other failures are unrelated to the quota, which the header reports on every response.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" && resp.StatusCode == http.StatusTooManyRequests {
+		return rateLimitReset(resp)
+	}
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// rateLimitReset extracts the delay until the rate limit window resets, X-RateLimit-Reset holds a Unix timestamp
+func rateLimitReset(resp *http.Response) (time.Duration, bool) {
+	epoch, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := time.Until(time.Unix(epoch, 0))
+	if delay < 0 {
+		delay = 0
+	}
+
+	return delay, true
+}
+
+// rewindBody restores the body of an already sent request
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+
+	return nil
+}
+
+// sleep waits for delay or until ctx is done
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
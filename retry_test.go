@@ -0,0 +1,154 @@
+package coinbase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBackoffBounds(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{5, 500 * time.Millisecond, time.Second},
+		{100, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 100; i++ {
+			if delay := p.backoff(test.attempt); delay < test.min || delay > test.max {
+				t.Fatalf("attempt %d: delay %s outside of [%s, %s]", test.attempt, delay, test.min, test.max)
+			}
+		}
+	}
+
+	if delay := (&RetryPolicy{}).backoff(3); delay != 0 {
+		t.Fatalf("delay without BaseDelay = %s", delay)
+	}
+}
+
+func response(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}}
+	for key, value := range headers {
+		resp.Header.Set(key, value)
+	}
+
+	return resp
+}
+
+func TestRetryAfter(t *testing.T) {
+	reset := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+
+	tests := []struct {
+		resp     *http.Response
+		ok       bool
+		min, max time.Duration
+	}{
+		{response(503, map[string]string{"Retry-After": "3"}), true, 3 * time.Second, 3 * time.Second},
+		{response(503, map[string]string{"Retry-After": "0"}), true, 0, 0},
+		{response(503, map[string]string{"Retry-After": time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}), true, 59 * time.Minute, time.Hour},
+		{response(503, map[string]string{"Retry-After": time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)}), true, 0, 0},
+		{response(503, map[string]string{"Retry-After": "-1"}), false, 0, 0},
+		{response(503, map[string]string{"Retry-After": "soon"}), false, 0, 0},
+		{response(503, nil), false, 0, 0},
+		// X-RateLimit-Reset is only a delay for 429
+		{response(429, map[string]string{"X-RateLimit-Reset": reset}), true, 58 * time.Second, time.Minute},
+		{response(503, map[string]string{"X-RateLimit-Reset": reset}), false, 0, 0},
+		{response(429, map[string]string{"Retry-After": "2", "X-RateLimit-Reset": reset}), true, 2 * time.Second, 2 * time.Second},
+		{nil, false, 0, 0},
+	}
+
+	for i, test := range tests {
+		delay, ok := retryAfter(test.resp)
+		if ok != test.ok || delay < test.min || delay > test.max {
+			t.Errorf("test %d: %s, %v, want %v in [%s, %s]", i, delay, ok, test.ok, test.min, test.max)
+		}
+	}
+}
+
+func TestRetryAfterClamped(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 30 * time.Second}
+
+	req := httptest.NewRequest("GET", "/accounts", nil)
+	err := &ErrorResponse{Response: response(429, map[string]string{"Retry-After": "3600"}), StatusCode: 429}
+
+	if delay, retry := p.retry(req, 1, err); !retry || delay != 30*time.Second {
+		t.Fatalf("retry = %s, %v, want 30s", delay, retry)
+	}
+
+	// Not retryable status
+	err = &ErrorResponse{Response: response(400, nil), StatusCode: 400}
+	if _, retry := p.retry(req, 1, err); retry {
+		t.Fatalf("400 retried")
+	}
+
+	if _, retry := p.retry(req, 3, &ErrorResponse{Response: response(503, nil), StatusCode: 503}); retry {
+		t.Fatalf("retried beyond MaxAttempts")
+	}
+}
+
+func TestRetryOnlyIdempotentRequests(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		method        string
+		idem          bool
+		nonIdempotent bool
+		attempts      int
+	}{
+		{"GET", false, false, 3},
+		{"DELETE", false, false, 3},
+		{"POST", false, false, 1},
+		{"POST", true, false, 3},
+		{"POST", false, true, 3},
+	}
+
+	for _, test := range tests {
+		c := NewClient(testAPIKey, testAPISecret)
+		c.APIBase = server.URL
+		c.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond, RetryNonIdempotent: test.nonIdempotent}
+
+		req, err := c.NewRequest(context.Background(), test.method, c.APIBase+"/accounts/a1/transactions", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.idem {
+			req = withIdempotency(req)
+		}
+
+		mu.Lock()
+		attempts = 0
+		mu.Unlock()
+
+		if err = c.SendWithAuth(req, &struct{}{}); err == nil {
+			t.Fatalf("%s: no error", test.method)
+		}
+
+		mu.Lock()
+		if attempts != test.attempts {
+			t.Errorf("%s idem %v, RetryNonIdempotent %v: %d attempts, want %d", test.method, test.idem, test.nonIdempotent, attempts, test.attempts)
+		}
+		mu.Unlock()
+	}
+}
@@ -0,0 +1,176 @@
+package coinbase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit bounds the search of the next matching time, specs such as "0 0 30 2 *" never match
+const cronSearchLimit = 5 * 366 * 24 * 60
+
+// intervalSchedule fires at every multiple of interval
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// cronSchedule fires at every minute matching all its fields
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// Every returns a Schedule firing at every multiple of interval since the zero time, e.g. on the hour for time.Hour.
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule{interval: interval}
+}
+
+// Next returns the first multiple of the interval after t
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	if s.interval <= 0 {
+		return time.Time{}
+	}
+
+	return t.Truncate(s.interval).Add(s.interval)
+}
+
+// ParseSchedule parses a schedule spec: a standard 5 fields cron expression (minute hour day-of-month month day-of-week)
+// such as "0 9 * * 1-5", "@every 24h", "@hourly", "@daily", "@weekly" or "@monthly".
+// Cron expressions are evaluated in the location of the time passed to Next.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("coinbase: invalid schedule %q", spec)
+		}
+		return Every(interval), nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("coinbase: invalid schedule %q, expected 5 fields", spec)
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	bits := [5]uint64{}
+
+	for i, field := range fields {
+		b, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("coinbase: invalid schedule %q: %v", spec, err)
+		}
+		bits[i] = b
+	}
+
+	// Sunday is both 0 and 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses a comma separated list of *, values, ranges and steps into a bit set
+func parseCronField(field string, min int, max int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			step = s
+			part = part[:i]
+		}
+
+		start, end := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err1, err2 error
+			start, err1 = strconv.Atoi(bounds[0])
+			end, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			value, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			start, end = value, value
+			if step > 1 {
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("value out of range %d-%d in %q", min, max, field)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// Next returns the first time after t matching the expression, zero if none is found
+func (s cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	for i := 0; i < cronSearchLimit; i++ {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// dayMatches applies the cron rule: when both day fields are restricted, either one matching is enough
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+
+	return dom || dow
+}
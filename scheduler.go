@@ -0,0 +1,361 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// schedulerIdle is how long Run waits when no recurring buy is scheduled
+const schedulerIdle = time.Minute
+
+// Scheduler errors
+var (
+	ErrPriceAboveCap     = errors.New("coinbase: price above the cap of the recurring buy")
+	ErrInvalidRecurring  = errors.New("coinbase: invalid recurring buy")
+	ErrDuplicateSchedule = errors.New("coinbase: recurring buy already scheduled")
+)
+
+// systemClock is the Clock of the local machine
+type systemClock struct{}
+
+// Now returns the current local time
+func (systemClock) Now() time.Time { return time.Now() }
+
+// After waits for d to elapse
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// scheduledBuy is a recurring buy with the time it was added, slots before it are never executed
+type scheduledBuy struct {
+	RecurringBuy
+	added time.Time
+}
+
+// Scheduler executes recurring buys (dollar-cost averaging). Every slot of a schedule is executed at most once:
+// it is claimed in the RunStore before quoting, so that a crash or a concurrent scheduler can never buy twice.
+// Slots missed while the scheduler was not running are skipped, only the latest due one is executed.
+type Scheduler struct {
+	// OnExecution, if set, is called with the outcome of every executed slot
+	OnExecution func(execution Execution)
+
+	client *Client
+	store  RunStore
+	clock  Clock
+
+	mu   sync.Mutex
+	buys []scheduledBuy
+	wake chan struct{} // Signaled by Add, so that Run recomputes its next slot
+}
+
+// NewScheduler returns a Scheduler placing buys through c and persisting run state in store.
+// clock defaults to the system clock when nil.
+func NewScheduler(c *Client, store RunStore, clock Clock) *Scheduler {
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	return &Scheduler{
+		client: c,
+		store:  store,
+		clock:  clock,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Add schedules a recurring buy, its first slot is the first one after now.
+func (s *Scheduler) Add(recurringBuy RecurringBuy) error {
+	switch {
+	case recurringBuy.ID == "" || recurringBuy.Schedule == nil || recurringBuy.AccountID == "" || recurringBuy.Currency == "":
+		return fmt.Errorf("%w: ID, Schedule, AccountID and Currency are required", ErrInvalidRecurring)
+	case (recurringBuy.Amount == nil) == (recurringBuy.Total == nil):
+		return fmt.Errorf("%w: exactly one of Amount and Total must be set", ErrInvalidRecurring)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, buy := range s.buys {
+		if buy.ID == recurringBuy.ID {
+			return fmt.Errorf("%w: %s", ErrDuplicateSchedule, recurringBuy.ID)
+		}
+	}
+
+	s.buys = append(s.buys, scheduledBuy{RecurringBuy: recurringBuy, added: s.clock.Now()})
+
+	// Run may be waiting for a later slot, or idle
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Run executes due slots until ctx is done, recurring buys can be added while it runs.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		s.RunPending(ctx)
+
+		wait := schedulerIdle
+		if next := s.next(); !next.IsZero() {
+			wait = next.Sub(s.clock.Now())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.clock.After(wait):
+		case <-s.wake:
+		}
+	}
+}
+
+// RunPending executes the recurring buys with a due slot and returns their outcome.
+func (s *Scheduler) RunPending(ctx context.Context) []Execution {
+	s.mu.Lock()
+	buys := append([]scheduledBuy{}, s.buys...)
+	s.mu.Unlock()
+
+	executions := []Execution{}
+
+	for _, buy := range buys {
+		slot, err := s.dueSlot(ctx, buy)
+		if err != nil {
+			executions = append(executions, s.report(Execution{RecurringBuyID: buy.ID, Err: err}))
+			continue
+		}
+
+		if slot.IsZero() {
+			continue
+		}
+
+		claimed, err := s.store.Claim(ctx, buy.ID, slot)
+		if err != nil {
+			executions = append(executions, s.report(Execution{RecurringBuyID: buy.ID, Slot: slot, Err: err}))
+			continue
+		}
+
+		// Another scheduler sharing the store executed it
+		if !claimed {
+			continue
+		}
+
+		executions = append(executions, s.report(s.execute(ctx, buy.RecurringBuy, slot)))
+	}
+
+	return executions
+}
+
+// dueSlot returns the latest slot not after now which was not executed yet, zero if none
+func (s *Scheduler) dueSlot(ctx context.Context, buy scheduledBuy) (time.Time, error) {
+	last, err := s.store.Last(ctx, buy.ID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if last.Before(buy.added) {
+		last = buy.added
+	}
+
+	now := s.clock.Now()
+
+	slot := time.Time{}
+	for next := buy.Schedule.Next(last); !next.IsZero() && !next.After(now); next = buy.Schedule.Next(next) {
+		slot = next
+	}
+
+	return slot, nil
+}
+
+// next returns the earliest upcoming slot of every recurring buy
+func (s *Scheduler) next() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	earliest := time.Time{}
+	for _, buy := range s.buys {
+		if next := buy.Schedule.Next(now); !next.IsZero() && (earliest.IsZero() || next.Before(earliest)) {
+			earliest = next
+		}
+	}
+
+	return earliest
+}
+
+// execute quotes the buy, checks the price cap and commits it
+func (s *Scheduler) execute(ctx context.Context, recurringBuy RecurringBuy, slot time.Time) Execution {
+	execution := Execution{RecurringBuyID: recurringBuy.ID, Slot: slot}
+
+	quote, err := s.client.QuoteBuy(ctx, recurringBuy.AccountID, PlaceBuy{
+		Amount:        recurringBuy.Amount,
+		Total:         recurringBuy.Total,
+		Currency:      recurringBuy.Currency,
+		PaymentMethod: recurringBuy.PaymentMethod,
+	}, nil)
+	if err != nil {
+		execution.Err = err
+		return execution
+	}
+
+	execution.Quote = quote
+
+	if recurringBuy.MaxPrice != nil && quote.Price.Cmp(recurringBuy.MaxPrice) > 0 {
+		quote.Cancel()
+		execution.Skipped = true
+		execution.Err = fmt.Errorf("%w: %s > %s", ErrPriceAboveCap, quote.Price, recurringBuy.MaxPrice)
+		return execution
+	}
+
+	trade, err := quote.Commit(ctx)
+	if err != nil {
+		execution.Err = err
+		return execution
+	}
+
+	execution.Buy = trade.(*Buy)
+
+	return execution
+}
+
+// report passes execution to OnExecution
+func (s *Scheduler) report(execution Execution) Execution {
+	if s.OnExecution != nil {
+		s.OnExecution(execution)
+	}
+
+	return execution
+}
+
+// MemoryRunStore is a RunStore keeping run state in memory, it is lost on restart.
+type MemoryRunStore struct {
+	mu    sync.Mutex
+	slots map[string]time.Time
+}
+
+// NewMemoryRunStore returns an empty MemoryRunStore.
+func NewMemoryRunStore() *MemoryRunStore {
+	return &MemoryRunStore{slots: map[string]time.Time{}}
+}
+
+// Claim records slot as executed for id, unless a slot not before it was already claimed
+func (m *MemoryRunStore) Claim(ctx context.Context, id string, slot time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if last, ok := m.slots[id]; ok && !slot.After(last) {
+		return false, nil
+	}
+
+	m.slots[id] = slot
+
+	return true, nil
+}
+
+// Last returns the last claimed slot of id
+func (m *MemoryRunStore) Last(ctx context.Context, id string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.slots[id], nil
+}
+
+// FileRunStore is a RunStore persisting run state in a JSON file.
+// It is safe for concurrent use within a process, not across processes.
+type FileRunStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileRunStore returns a FileRunStore writing to path, the file is created on the first claim.
+func NewFileRunStore(path string) *FileRunStore {
+	return &FileRunStore{path: path}
+}
+
+// Claim records slot as executed for id, unless a slot not before it was already claimed
+func (f *FileRunStore) Claim(ctx context.Context, id string, slot time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	slots, err := f.load()
+	if err != nil {
+		return false, err
+	}
+
+	if last, ok := slots[id]; ok && !slot.After(last) {
+		return false, nil
+	}
+
+	slots[id] = slot
+
+	return true, writeJSONFile(f.path, slots)
+}
+
+// Last returns the last claimed slot of id
+func (f *FileRunStore) Last(ctx context.Context, id string) (time.Time, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	slots, err := f.load()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return slots[id], nil
+}
+
+// load reads the slots from the file, a missing file holds no slots
+func (f *FileRunStore) load() (map[string]time.Time, error) {
+	slots := map[string]time.Time{}
+
+	if err := readJSONFile(f.path, &slots); err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+// readJSONFile decodes the JSON file at path into v, a missing file leaves v unchanged
+func readJSONFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// writeJSONFile atomically replaces the file at path with v encoded as JSON
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
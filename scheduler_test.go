@@ -0,0 +1,405 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tradeServer places and commits buys of 0.001 BTC at price USD per BTC
+type tradeServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	price   string
+	placed  []map[string]interface{} // Bodies of the placed buys
+	commits []string                 // IDs of the committed buys
+}
+
+func newTradeServer(t *testing.T, price string) *tradeServer {
+	s := &tradeServer{price: price}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		subtotal := MustParseDecimal(s.price).Mul(MustParseDecimal("0.001"))
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/accounts/a1/buys":
+			body := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("buy body: %v", err)
+			}
+			s.placed = append(s.placed, body)
+			fmt.Fprintf(w, `{"data":{"id":"b%d","amount":{"amount":"0.001","currency":"BTC"},"subtotal":{"amount":"%s","currency":"USD"}}}`, len(s.placed), subtotal)
+		case r.Method == "POST" && strings.HasPrefix(r.URL.Path, "/accounts/a1/buys/") && strings.HasSuffix(r.URL.Path, "/commit"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/accounts/a1/buys/"), "/commit")
+			s.commits = append(s.commits, id)
+			fmt.Fprintf(w, `{"data":{"id":%q,"committed":true,"amount":{"amount":"0.001","currency":"BTC"},"subtotal":{"amount":"%s","currency":"USD"}}}`, id, subtotal)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return s
+}
+
+func (s *tradeServer) setPrice(price string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.price = price
+}
+
+func (s *tradeServer) committed() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.commits...)
+}
+
+func (s *tradeServer) client() *Client {
+	c := NewClient(testAPIKey, testAPISecret)
+	c.APIBase = s.URL
+
+	return c
+}
+
+// pendingTimers returns the number of timers not fired yet
+func (f *fakeClock) pendingTimers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.timers)
+}
+
+// waitTimers waits until n timers are pending, Run registers them from another goroutine
+func waitTimers(t *testing.T, clock *fakeClock, n int) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); clock.pendingTimers() < n; {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d timers pending, want %d", clock.pendingTimers(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func hourlyBuy(id string) RecurringBuy {
+	return RecurringBuy{ID: id, Schedule: Every(time.Hour), AccountID: "a1", Currency: "BTC", Amount: MustParseDecimal("0.001")}
+}
+
+func TestParseSchedule(t *testing.T) {
+	from := time.Date(2024, 3, 4, 10, 30, 0, 0, time.UTC) // Monday
+
+	tests := []struct {
+		spec string
+		next []string
+	}{
+		{"*/15 * * * *", []string{"2024-03-04 10:45", "2024-03-04 11:00", "2024-03-04 11:15"}},
+		{"5 9-11 * * *", []string{"2024-03-04 11:05", "2024-03-05 09:05", "2024-03-05 10:05"}},
+		{"0 8-20/6 * * *", []string{"2024-03-04 14:00", "2024-03-04 20:00", "2024-03-05 08:00"}},
+		{"10/20 10 * * *", []string{"2024-03-04 10:50", "2024-03-05 10:10", "2024-03-05 10:30"}},
+		{"0 12 * * 1,3", []string{"2024-03-04 12:00", "2024-03-06 12:00", "2024-03-11 12:00"}},
+		{"0 9 * * 6-7", []string{"2024-03-09 09:00", "2024-03-10 09:00", "2024-03-16 09:00"}},
+		{"0 0 29 2 *", []string{"2028-02-29 00:00"}},
+		// Both day fields restricted, either matches: the 15th or Fridays
+		{"0 0 15 * 5", []string{"2024-03-08 00:00", "2024-03-15 00:00", "2024-03-22 00:00"}},
+		// Only the day of month restricted
+		{"0 0 15 * *", []string{"2024-03-15 00:00", "2024-04-15 00:00"}},
+		{"@daily", []string{"2024-03-05 00:00", "2024-03-06 00:00"}},
+		{"@weekly", []string{"2024-03-10 00:00", "2024-03-17 00:00"}},
+		{"@monthly", []string{"2024-04-01 00:00", "2024-05-01 00:00"}},
+		{"@every 90m", []string{"2024-03-04 12:00", "2024-03-04 13:30"}},
+		{"0 0 30 2 *", []string{""}},
+	}
+
+	for _, test := range tests {
+		schedule, err := ParseSchedule(test.spec)
+		if err != nil {
+			t.Errorf("%s: %v", test.spec, err)
+			continue
+		}
+
+		at := from
+		for _, want := range test.next {
+			at = schedule.Next(at)
+			got := ""
+			if !at.IsZero() {
+				got = at.Format("2006-01-02 15:04")
+			}
+			if got != want {
+				t.Errorf("%s: next = %q, want %q", test.spec, got, want)
+				break
+			}
+		}
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "1-b * * * *", "@every", "@every -1h", "@yearly"} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("%q: accepted", spec)
+		}
+	}
+}
+
+func TestDueSlot(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC))
+	s := NewScheduler(nil, NewMemoryRunStore(), clock)
+
+	if err := s.Add(hourlyBuy("r1")); err != nil {
+		t.Fatal(err)
+	}
+
+	slot, err := s.dueSlot(context.Background(), s.buys[0])
+	if err != nil || !slot.IsZero() {
+		t.Fatalf("slot before the first one = %s, %v", slot, err)
+	}
+
+	// Only the latest of the missed slots is due
+	clock.Advance(2*time.Hour + 40*time.Minute)
+	slot, err = s.dueSlot(context.Background(), s.buys[0])
+	if err != nil || !slot.Equal(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("slot = %s, %v, want 12:00", slot, err)
+	}
+
+	if _, err = s.store.Claim(context.Background(), "r1", slot); err != nil {
+		t.Fatal(err)
+	}
+	slot, err = s.dueSlot(context.Background(), s.buys[0])
+	if err != nil || !slot.IsZero() {
+		t.Fatalf("slot after claiming 12:00 = %s, %v", slot, err)
+	}
+}
+
+func TestSchedulerAdd(t *testing.T) {
+	s := NewScheduler(nil, NewMemoryRunStore(), nil)
+
+	invalid := []RecurringBuy{
+		{Schedule: Every(time.Hour), AccountID: "a1", Currency: "BTC", Amount: MustParseDecimal("1")},
+		{ID: "r1", AccountID: "a1", Currency: "BTC", Amount: MustParseDecimal("1")},
+		{ID: "r1", Schedule: Every(time.Hour), AccountID: "a1", Currency: "BTC"},
+		{ID: "r1", Schedule: Every(time.Hour), AccountID: "a1", Currency: "BTC", Amount: MustParseDecimal("1"), Total: MustParseDecimal("1")},
+	}
+	for _, recurringBuy := range invalid {
+		if err := s.Add(recurringBuy); !errors.Is(err, ErrInvalidRecurring) {
+			t.Errorf("%+v: %v, want %v", recurringBuy, err, ErrInvalidRecurring)
+		}
+	}
+
+	if err := s.Add(hourlyBuy("r1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(hourlyBuy("r1")); !errors.Is(err, ErrDuplicateSchedule) {
+		t.Fatalf("second add: %v, want %v", err, ErrDuplicateSchedule)
+	}
+}
+
+// testClaimOnce claims the same slot concurrently, exactly one claim must succeed
+func testClaimOnce(t *testing.T, store RunStore) {
+	slot := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		claimed int
+	)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ok, err := store.Claim(context.Background(), "r1", slot)
+			if err != nil {
+				t.Error(err)
+			}
+			if ok {
+				mu.Lock()
+				claimed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if claimed != 1 {
+		t.Fatalf("slot claimed %d times, want once", claimed)
+	}
+
+	if ok, _ := store.Claim(context.Background(), "r1", slot.Add(-time.Hour)); ok {
+		t.Fatalf("slot before the last one claimed")
+	}
+	if ok, _ := store.Claim(context.Background(), "r2", slot); !ok {
+		t.Fatalf("slot of another recurring buy not claimed")
+	}
+
+	if last, err := store.Last(context.Background(), "r1"); err != nil || !last.Equal(slot) {
+		t.Fatalf("last = %s, %v, want %s", last, err, slot)
+	}
+	if last, err := store.Last(context.Background(), "r3"); err != nil || !last.IsZero() {
+		t.Fatalf("last of an unknown recurring buy = %s, %v", last, err)
+	}
+}
+
+func TestMemoryRunStoreClaim(t *testing.T) {
+	testClaimOnce(t, NewMemoryRunStore())
+}
+
+func TestFileRunStoreClaim(t *testing.T) {
+	dir, err := ioutil.TempDir("", "coinbase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "runs.json")
+	testClaimOnce(t, NewFileRunStore(path))
+
+	// Another store on the same file sees the claims
+	if ok, err := NewFileRunStore(path).Claim(context.Background(), "r1", time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)); ok || err != nil {
+		t.Fatalf("claim from a reopened store = %v, %v", ok, err)
+	}
+}
+
+func TestSchedulerMaxPrice(t *testing.T) {
+	server := newTradeServer(t, "60000")
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC))
+	s := NewScheduler(server.client(), NewMemoryRunStore(), clock)
+
+	recurringBuy := hourlyBuy("r1")
+	recurringBuy.MaxPrice = MustParseDecimal("50000")
+	if err := s.Add(recurringBuy); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(30 * time.Minute)
+	executions := s.RunPending(context.Background())
+	if len(executions) != 1 || !executions[0].Skipped || !errors.Is(executions[0].Err, ErrPriceAboveCap) || executions[0].Buy != nil {
+		t.Fatalf("executions above the cap = %+v", executions)
+	}
+	if len(server.committed()) != 0 {
+		t.Fatalf("buy committed above the cap: %v", server.committed())
+	}
+
+	// The skipped slot is not attempted again
+	if executions = s.RunPending(context.Background()); len(executions) != 0 {
+		t.Fatalf("skipped slot executed again: %+v", executions)
+	}
+
+	server.setPrice("50000")
+	clock.Advance(time.Hour)
+	executions = s.RunPending(context.Background())
+	if len(executions) != 1 || executions[0].Err != nil || executions[0].Buy == nil || !executions[0].Buy.Committed {
+		t.Fatalf("executions at the cap = %+v", executions)
+	}
+	if commits := server.committed(); len(commits) != 1 || commits[0] != "b2" {
+		t.Fatalf("commits = %v, want [b2]", commits)
+	}
+}
+
+func TestSchedulerRestart(t *testing.T) {
+	server := newTradeServer(t, "50000")
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "coinbase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "runs.json")
+	clock := newFakeClock(time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC))
+
+	s := NewScheduler(server.client(), NewFileRunStore(path), clock)
+	if err = s.Add(hourlyBuy("r1")); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(30 * time.Minute)
+	if executions := s.RunPending(context.Background()); len(executions) != 1 || executions[0].Err != nil {
+		t.Fatalf("executions = %+v", executions)
+	}
+
+	// Restarted within the same slot: the claimed slot is not executed again
+	restarted := NewScheduler(server.client(), NewFileRunStore(path), clock)
+	if err = restarted.Add(hourlyBuy("r1")); err != nil {
+		t.Fatal(err)
+	}
+	if executions := restarted.RunPending(context.Background()); len(executions) != 0 {
+		t.Fatalf("claimed slot executed after restart: %+v", executions)
+	}
+
+	// Slots missed while down are skipped, the next one is executed
+	clock.Advance(3 * time.Hour)
+	restarted = NewScheduler(server.client(), NewFileRunStore(path), clock)
+	if err = restarted.Add(hourlyBuy("r1")); err != nil {
+		t.Fatal(err)
+	}
+	if executions := restarted.RunPending(context.Background()); len(executions) != 0 {
+		t.Fatalf("slots missed while down executed: %+v", executions)
+	}
+
+	clock.Advance(time.Hour)
+	executions := restarted.RunPending(context.Background())
+	if len(executions) != 1 || !executions[0].Slot.Equal(time.Date(2024, 3, 4, 14, 0, 0, 0, time.UTC)) {
+		t.Fatalf("executions = %+v, want the 14:00 slot", executions)
+	}
+
+	if commits := server.committed(); len(commits) != 2 {
+		t.Fatalf("commits = %v, want 2", commits)
+	}
+}
+
+func TestSchedulerRunWakesOnAdd(t *testing.T) {
+	server := newTradeServer(t, "50000")
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2024, 3, 4, 10, 0, 30, 0, time.UTC))
+	s := NewScheduler(server.client(), NewMemoryRunStore(), clock)
+
+	executed := make(chan Execution, 1)
+	s.OnExecution = func(execution Execution) { executed <- execution }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	// Idle, nothing scheduled
+	waitTimers(t, clock, 1)
+
+	if err := s.Add(hourlyBuy("r1")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run waits for the 11:00 slot rather than the end of its idle period
+	waitTimers(t, clock, 2)
+	clock.Advance(59*time.Minute + 30*time.Second)
+
+	select {
+	case execution := <-executed:
+		if execution.Err != nil || !execution.Slot.Equal(time.Date(2024, 3, 4, 11, 0, 0, 0, time.UTC)) {
+			t.Fatalf("execution = %+v", execution)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("slot not executed")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Run = %v, want %v", err, context.Canceled)
+	}
+}
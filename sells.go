@@ -2,17 +2,18 @@ package coinbase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
 // ListSells Lists sells for an account.
 // Endpoint: GET /accounts/:account_id/sells
-func (c *Client) ListSells(ctx context.Context, accountID string) (*[]Sell, *Pagination, error) {
+func (c *Client) ListSells(ctx context.Context, accountID string, opts *ListOptions) (*[]Sell, *Pagination, error) {
 	sells := &[]Sell{}
 
 	pagination := &Pagination{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "sells"), nil)
+	req, err := c.NewRequest(ctx, "GET", withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "sells"), opts), nil)
 	if err != nil {
 		return sells, pagination, err
 	}
@@ -24,12 +25,35 @@ func (c *Client) ListSells(ctx context.Context, accountID string) (*[]Sell, *Pag
 	return sells, pagination, nil
 }
 
+// IterateSells Returns an Iterator over all sells for an account, following pagination automatically.
+// Every Value is a Sell.
+func (c *Client) IterateSells(ctx context.Context, accountID string, opts *ListOptions) *Iterator {
+	return c.newIterator(ctx, withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "sells"), opts), func(data []byte) (interface{}, error) {
+		sell := Sell{}
+		err := json.Unmarshal(data, &sell)
+		return sell, err
+	})
+}
+
+// ListAllSells Lists all sells for an account, fetching every page.
+func (c *Client) ListAllSells(ctx context.Context, accountID string, opts *ListOptions) (*[]Sell, error) {
+	sells := &[]Sell{}
+
+	it := c.IterateSells(ctx, accountID, opts)
+	for it.Next() {
+		*sells = append(*sells, it.Value().(Sell))
+	}
+
+	return sells, it.Err()
+}
+
 // GetSell Show an individual sell.
+// Related resources listed in expand, e.g. ExpandAll, are embedded in the response.
 // Endpoint: GET /accounts/:account_id/sells/:sell_id
-func (c *Client) GetSell(ctx context.Context, accountID string, sellID string) (*Sell, error) {
+func (c *Client) GetSell(ctx context.Context, accountID string, sellID string, expand ...string) (*Sell, error) {
 	sell := &Sell{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "sells", sellID), nil)
+	req, err := c.NewRequest(ctx, "GET", withExpand(fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "sells", sellID), expand), nil)
 	if err != nil {
 		return sell, err
 	}
@@ -51,7 +75,16 @@ func (c *Client) PlaceSell(ctx context.Context, accountID string, sellData Place
 		return sell, err
 	}
 
-	if err = c.SendWithAuth(req, sell); err != nil {
+	send := func() error { return c.SendWithAuth(req, sell) }
+
+	// Quotes do not move money
+	if sellData.Quote {
+		err = send()
+	} else {
+		err = c.guard(ctx, sellAction(accountID, sellData), send)
+	}
+
+	if err != nil {
 		return sell, err
 	}
 
@@ -0,0 +1,281 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// syncPageLimit is the page size used by the Syncer, the maximum accepted by the API
+const syncPageLimit = 100
+
+// Kinds of SyncEvent
+const (
+	SyncEventNewTransaction SyncEventType = "new_transaction"
+	SyncEventStatusChanged  SyncEventType = "status_changed"
+)
+
+// Syncer detects new transactions and status changes across every account, without downloading the whole history
+// again: for each account it lists only transactions after a stored cursor, in ascending order, and re-fetches the
+// transactions which were not final yet. Events are delivered at least once, a sync interrupted before its state
+// is saved emits them again, and so does a full resync when the API no longer accepts the stored cursor.
+type Syncer struct {
+	// OnError, if set, is called by Run with the error of every failed pass. Otherwise errors are written to the client Log
+	OnError func(err error)
+
+	client *Client
+	store  SyncStore
+	events chan<- SyncEvent
+}
+
+// NewSyncer returns a Syncer reading through c, persisting state in store and sending events on events.
+// Sync blocks while events is full, the caller owns the channel and closes it once syncing is over.
+func NewSyncer(c *Client, store SyncStore, events chan<- SyncEvent) *Syncer {
+	return &Syncer{
+		client: c,
+		store:  store,
+		events: events,
+	}
+}
+
+// Run syncs every interval until ctx is done. Failed passes are reported and retried on the next interval.
+func (s *Syncer) Run(ctx context.Context, interval time.Duration) error {
+	for {
+		if err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+			s.report(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Sync runs one pass over every account. The first pass of an account reports its whole history.
+// An account failing to sync does not stop the others, the first error is returned once all were tried.
+func (s *Syncer) Sync(ctx context.Context) error {
+	var first error
+
+	it := s.client.IterateAccounts(ctx, &ListOptions{Limit: syncPageLimit})
+	for it.Next() {
+		if err := s.syncAccount(ctx, it.Value().(Account).ID); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			if first == nil {
+				first = err
+			}
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	return first
+}
+
+// syncAccount reports the status changes of pending transactions and the new transactions of an account.
+// The progress made is saved even when the sync fails halfway.
+func (s *Syncer) syncAccount(ctx context.Context, accountID string) error {
+	state, err := s.store.Load(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if state.Pending == nil {
+		state.Pending = map[string]TransactionStatus{}
+	}
+
+	err = s.update(ctx, accountID, &state)
+
+	if saveErr := s.store.Save(ctx, accountID, state); saveErr != nil && err == nil {
+		err = saveErr
+	}
+
+	return err
+}
+
+// update advances the state of an account, emitting the events found along the way
+func (s *Syncer) update(ctx context.Context, accountID string, state *SyncState) error {
+	for id, previous := range state.Pending {
+		transaction, err := s.client.GetTransaction(ctx, accountID, id)
+		// Deleted transactions, such as canceled money requests, are no longer tracked
+		if errors.Is(err, ErrNotFound) {
+			delete(state.Pending, id)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if transaction.Status != previous {
+			if err = s.emit(ctx, SyncEvent{Type: SyncEventStatusChanged, AccountID: accountID, Transaction: transaction, PreviousStatus: previous}); err != nil {
+				return err
+			}
+		}
+
+		s.track(state, transaction)
+	}
+
+	cursor := state.Cursor
+	received, err := s.listNew(ctx, accountID, state)
+
+	// The cursor transaction no longer exists or is refused, the whole history is listed again
+	if cursor != "" && !received && (errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidRequest) || errors.Is(err, ErrValidation)) {
+		state.Cursor = ""
+		_, err = s.listNew(ctx, accountID, state)
+	}
+
+	return err
+}
+
+// listNew emits the transactions after the cursor of an account, advancing it.
+// Oldest first, so that the cursor only moves forward and an interrupted sync resumes where it stopped:
+// in ascending order starting_after returns the newer transactions, with pages following next_uri.
+// It reports whether any transaction was received.
+func (s *Syncer) listNew(ctx context.Context, accountID string, state *SyncState) (bool, error) {
+	received := false
+
+	it := s.client.IterateTransactions(ctx, accountID, &ListOptions{Limit: syncPageLimit, Order: OrderAsc, StartingAfter: state.Cursor})
+	for it.Next() {
+		received = true
+		transaction := it.Value().(Transaction)
+
+		if err := s.emit(ctx, SyncEvent{Type: SyncEventNewTransaction, AccountID: accountID, Transaction: &transaction}); err != nil {
+			return received, err
+		}
+
+		s.track(state, &transaction)
+		state.Cursor = transaction.ID
+	}
+
+	return received, it.Err()
+}
+
+// track watches transaction until its status is final
+func (s *Syncer) track(state *SyncState, transaction *Transaction) {
+	if transaction.Status.Final() {
+		delete(state.Pending, transaction.ID)
+		return
+	}
+
+	state.Pending[transaction.ID] = transaction.Status
+}
+
+// report passes err to OnError or, if not set, writes it to the client Log
+func (s *Syncer) report(err error) {
+	if s.OnError != nil {
+		s.OnError(err)
+		return
+	}
+
+	if s.client.Log != nil {
+		s.client.Log.Write([]byte(fmt.Sprintf("Syncer: %v\n", err)))
+	}
+}
+
+// emit sends event, unless ctx is done first
+func (s *Syncer) emit(ctx context.Context, event SyncEvent) error {
+	select {
+	case s.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// MemorySyncStore is a SyncStore keeping state in memory, it is lost on restart.
+type MemorySyncStore struct {
+	mu     sync.Mutex
+	states map[string]SyncState
+}
+
+// NewMemorySyncStore returns an empty MemorySyncStore.
+func NewMemorySyncStore() *MemorySyncStore {
+	return &MemorySyncStore{states: map[string]SyncState{}}
+}
+
+// Load returns the state of an account, empty if it was never synced
+func (m *MemorySyncStore) Load(ctx context.Context, accountID string) (SyncState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return copySyncState(m.states[accountID]), nil
+}
+
+// Save stores the state of an account
+func (m *MemorySyncStore) Save(ctx context.Context, accountID string, state SyncState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.states[accountID] = copySyncState(state)
+
+	return nil
+}
+
+// copySyncState returns a copy of state which does not share its Pending map
+func copySyncState(state SyncState) SyncState {
+	pending := make(map[string]TransactionStatus, len(state.Pending))
+	for id, status := range state.Pending {
+		pending[id] = status
+	}
+	state.Pending = pending
+
+	return state
+}
+
+// FileSyncStore is a SyncStore persisting state in a JSON file.
+// It is safe for concurrent use within a process, not across processes.
+type FileSyncStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSyncStore returns a FileSyncStore writing to path, the file is created on the first save.
+func NewFileSyncStore(path string) *FileSyncStore {
+	return &FileSyncStore{path: path}
+}
+
+// Load returns the state of an account, empty if it was never synced
+func (f *FileSyncStore) Load(ctx context.Context, accountID string) (SyncState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	states, err := f.load()
+	if err != nil {
+		return SyncState{}, err
+	}
+
+	return states[accountID], nil
+}
+
+// Save stores the state of an account
+func (f *FileSyncStore) Save(ctx context.Context, accountID string, state SyncState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	states, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	states[accountID] = state
+
+	return writeJSONFile(f.path, states)
+}
+
+// load reads the states from the file, a missing file holds no state
+func (f *FileSyncStore) load() (map[string]SyncState, error) {
+	states := map[string]SyncState{}
+
+	if err := readJSONFile(f.path, &states); err != nil {
+		return nil, err
+	}
+
+	return states, nil
+}
@@ -0,0 +1,292 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncServer serves the transactions of the account a1, two per page in ascending order
+type syncServer struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	transactions []Transaction
+	cursors      []string // starting_after of every listing
+	failAccounts bool
+}
+
+func newSyncServer(t *testing.T) *syncServer {
+	s := &syncServer{}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/accounts":
+			if s.failAccounts {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"data":[{"id":"a1"}]}`)
+		case r.URL.Path == "/accounts/a1/transactions":
+			if r.URL.Query().Get("order") != OrderAsc {
+				t.Errorf("transactions listed in %q order", r.URL.Query().Get("order"))
+			}
+			s.list(w, r.URL.Query().Get("starting_after"))
+		case strings.HasPrefix(r.URL.Path, "/accounts/a1/transactions/"):
+			id := strings.TrimPrefix(r.URL.Path, "/accounts/a1/transactions/")
+			for _, transaction := range s.transactions {
+				if transaction.ID == id {
+					writeData(t, w, transaction, nil)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return s
+}
+
+// list writes the page of transactions after cursor, an unknown cursor is not found
+func (s *syncServer) list(w http.ResponseWriter, cursor string) {
+	s.cursors = append(s.cursors, cursor)
+
+	start := 0
+	if cursor != "" {
+		start = -1
+		for i, transaction := range s.transactions {
+			if transaction.ID == cursor {
+				start = i + 1
+			}
+		}
+		if start < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[{"id":"not_found","message":"Not found"}]}`)
+			return
+		}
+	}
+
+	end := start + 2
+	if end > len(s.transactions) {
+		end = len(s.transactions)
+	}
+
+	page := s.transactions[start:end]
+
+	pagination := Pagination{}
+	if end < len(s.transactions) {
+		pagination.NextUri = "/accounts/a1/transactions?order=asc&starting_after=" + page[len(page)-1].ID
+	}
+
+	writeData(nil, w, page, pagination)
+}
+
+func writeData(t *testing.T, w http.ResponseWriter, data interface{}, pagination interface{}) {
+	if err := json.NewEncoder(w).Encode(Response{Data: data, Pagination: pagination}); err != nil && t != nil {
+		t.Error(err)
+	}
+}
+
+func (s *syncServer) add(id string, status TransactionStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.transactions = append(s.transactions, Transaction{ID: id, Status: status})
+}
+
+func (s *syncServer) setStatus(id string, status TransactionStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.transactions {
+		if s.transactions[i].ID == id {
+			s.transactions[i].Status = status
+		}
+	}
+}
+
+// remove deletes the transaction id from the history
+func (s *syncServer) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.transactions {
+		if s.transactions[i].ID == id {
+			s.transactions = append(s.transactions[:i], s.transactions[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *syncServer) listedCursors() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors := s.cursors
+	s.cursors = nil
+
+	return cursors
+}
+
+func (s *syncServer) client() *Client {
+	c := NewClient(testAPIKey, testAPISecret)
+	c.APIBase = s.URL
+
+	return c
+}
+
+// drain returns the events sent so far, as "type id"
+func drain(events chan SyncEvent) []string {
+	received := []string{}
+	for {
+		select {
+		case event := <-events:
+			received = append(received, string(event.Type)+" "+event.Transaction.ID)
+		default:
+			return received
+		}
+	}
+}
+
+func TestSyncerResumesFromCursor(t *testing.T) {
+	server := newSyncServer(t)
+	defer server.Close()
+
+	server.add("t1", TransactionStatusCompleted)
+	server.add("t2", TransactionStatusPending)
+	server.add("t3", TransactionStatusCompleted)
+
+	events := make(chan SyncEvent, 10)
+	store := NewMemorySyncStore()
+	syncer := NewSyncer(server.client(), store, events)
+
+	// Two pages, the first pass reports the whole history
+	if err := syncer.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(drain(events), ", "); got != "new_transaction t1, new_transaction t2, new_transaction t3" {
+		t.Fatalf("first pass events: %s", got)
+	}
+	if cursors := server.listedCursors(); len(cursors) != 2 || cursors[0] != "" || cursors[1] != "t2" {
+		t.Fatalf("first pass cursors = %q", cursors)
+	}
+
+	state, _ := store.Load(context.Background(), "a1")
+	if state.Cursor != "t3" || len(state.Pending) != 1 || state.Pending["t2"] != TransactionStatusPending {
+		t.Fatalf("state = %+v", state)
+	}
+
+	// The next pass resumes after t3 and follows the pending t2
+	server.add("t4", TransactionStatusCompleted)
+	server.setStatus("t2", TransactionStatusCompleted)
+
+	if err := syncer.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(drain(events), ", "); got != "status_changed t2, new_transaction t4" {
+		t.Fatalf("second pass events: %s", got)
+	}
+	if cursors := server.listedCursors(); len(cursors) != 1 || cursors[0] != "t3" {
+		t.Fatalf("second pass cursors = %q", cursors)
+	}
+
+	state, _ = store.Load(context.Background(), "a1")
+	if state.Cursor != "t4" || len(state.Pending) != 0 {
+		t.Fatalf("state = %+v", state)
+	}
+}
+
+func TestSyncerResyncsOnUnknownCursor(t *testing.T) {
+	server := newSyncServer(t)
+	defer server.Close()
+
+	server.add("t1", TransactionStatusCompleted)
+	server.add("t2", TransactionStatusCompleted)
+
+	events := make(chan SyncEvent, 10)
+	store := NewMemorySyncStore()
+	syncer := NewSyncer(server.client(), store, events)
+
+	if err := syncer.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	drain(events)
+	server.listedCursors()
+
+	// The cursor transaction was deleted
+	server.remove("t2")
+	server.add("t3", TransactionStatusCompleted)
+
+	if err := syncer.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(drain(events), ", "); got != "new_transaction t1, new_transaction t3" {
+		t.Fatalf("resync events: %s", got)
+	}
+	if cursors := server.listedCursors(); len(cursors) != 2 || cursors[0] != "t2" || cursors[1] != "" {
+		t.Fatalf("resync cursors = %q", cursors)
+	}
+
+	if state, _ := store.Load(context.Background(), "a1"); state.Cursor != "t3" {
+		t.Fatalf("cursor after resync = %s, want t3", state.Cursor)
+	}
+}
+
+func TestSyncerRunReportsErrors(t *testing.T) {
+	server := newSyncServer(t)
+	defer server.Close()
+
+	server.failAccounts = true
+	server.add("t1", TransactionStatusCompleted)
+
+	events := make(chan SyncEvent, 10)
+	syncer := NewSyncer(server.client(), NewMemorySyncStore(), events)
+
+	failures := make(chan error, 100)
+	syncer.OnError = func(err error) { failures <- err }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- syncer.Run(ctx, time.Millisecond) }()
+
+	select {
+	case err := <-failures:
+		if !errors.Is(err, ErrRetryLater) {
+			t.Errorf("reported error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("error not reported")
+	}
+
+	// Run keeps polling after a failed pass
+	server.mu.Lock()
+	server.failAccounts = false
+	server.mu.Unlock()
+
+	select {
+	case event := <-events:
+		if event.Transaction.ID != "t1" {
+			t.Errorf("event = %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no sync after the failed pass")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Run = %v, want %v", err, context.Canceled)
+	}
+}
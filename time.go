@@ -15,7 +15,7 @@ func (c *Client) GetTime(ctx context.Context) (*Time, error) {
 		return time, err
 	}
 
-	if err = c.SendWithAuth(req, time); err != nil {
+	if err = c.Send(req, time); err != nil {
 		return time, err
 	}
 
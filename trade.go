@@ -0,0 +1,98 @@
+package coinbase
+
+// GetID returns the ID of the buy
+func (b Buy) GetID() string { return b.ID }
+
+// GetStatus returns the status of the buy
+func (b Buy) GetStatus() string { return b.Status }
+
+// GetAmount returns the amount of cryptocurrency bought
+func (b Buy) GetAmount() Money { return b.Amount }
+
+// GetSubTotal returns the amount paid, excluding fees
+func (b Buy) GetSubTotal() Money { return b.SubTotal }
+
+// GetFee returns the fees of the buy
+func (b Buy) GetFee() Money { return b.Fee }
+
+// GetTotal returns the amount paid, including fees
+func (b Buy) GetTotal() Money { return b.Total }
+
+// GetID returns the ID of the sell
+func (s Sell) GetID() string { return s.ID }
+
+// GetStatus returns the status of the sell
+func (s Sell) GetStatus() string { return s.Status }
+
+// GetAmount returns the amount of cryptocurrency sold
+func (s Sell) GetAmount() Money { return s.Amount }
+
+// GetSubTotal returns the amount received, excluding fees
+func (s Sell) GetSubTotal() Money { return s.SubTotal }
+
+// GetFee returns the fees of the sell
+func (s Sell) GetFee() Money { return s.Fee }
+
+// GetTotal returns the amount received, including fees
+func (s Sell) GetTotal() Money { return s.Total }
+
+// GetID returns the ID of the deposit
+func (d Deposit) GetID() string { return d.ID }
+
+// GetStatus returns the status of the deposit
+func (d Deposit) GetStatus() string { return d.Status }
+
+// GetAmount returns the amount deposited
+func (d Deposit) GetAmount() Money { return d.Amount }
+
+// GetSubTotal returns the amount deposited, excluding fees
+func (d Deposit) GetSubTotal() Money { return d.SubTotal }
+
+// GetFee returns the fees of the deposit
+func (d Deposit) GetFee() Money { return d.Fee }
+
+// GetTotal returns the amount deposited, deposits have no separate total
+func (d Deposit) GetTotal() Money { return d.Amount }
+
+// GetID returns the ID of the withdrawal
+func (w Withdrawal) GetID() string { return w.ID }
+
+// GetStatus returns the status of the withdrawal
+func (w Withdrawal) GetStatus() string { return w.Status }
+
+// GetAmount returns the amount withdrawn
+func (w Withdrawal) GetAmount() Money { return w.Amount }
+
+// GetSubTotal returns the amount withdrawn, excluding fees
+func (w Withdrawal) GetSubTotal() Money { return w.SubTotal }
+
+// GetFee returns the fees of the withdrawal
+func (w Withdrawal) GetFee() Money { return w.Fee }
+
+// GetTotal returns the amount withdrawn, withdrawals have no separate total
+func (w Withdrawal) GetTotal() Money { return w.Amount }
+
+// SumFees returns the sum of the fees of trades, which must all be charged in the same currency.
+func SumFees(trades ...Trade) (Money, error) {
+	sum := Money{}
+
+	for i, trade := range trades {
+		fee := trade.GetFee()
+		if i == 0 {
+			sum = fee
+			continue
+		}
+
+		var err error
+		if sum, err = sum.Add(fee); err != nil {
+			return Money{}, err
+		}
+	}
+
+	return sum, nil
+}
+
+// Bool returns a pointer to v, e.g. PlaceBuy{Commit: Bool(false)} for a buy committed later with CommitBuy.
+func Bool(v bool) *bool {
+	return &v
+}
@@ -2,17 +2,22 @@ package coinbase
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/AlessandroSechi/go-coinbase/validation"
 )
 
 // ListTransactions Lists account’s transactions.
 // Endpoint: GET /accounts/:account_id/transactions
-func (c *Client) ListTransactions(ctx context.Context, accountID string) (*[]Transaction, *Pagination, error) {
+func (c *Client) ListTransactions(ctx context.Context, accountID string, opts *ListOptions) (*[]Transaction, *Pagination, error) {
 	transactions := &[]Transaction{}
 
 	pagination := &Pagination{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "transactions"), nil)
+	req, err := c.NewRequest(ctx, "GET", withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "transactions"), opts), nil)
 	if err != nil {
 		return transactions, pagination, err
 	}
@@ -24,12 +29,35 @@ func (c *Client) ListTransactions(ctx context.Context, accountID string) (*[]Tra
 	return transactions, pagination, nil
 }
 
+// IterateTransactions Returns an Iterator over all account’s transactions, following pagination automatically.
+// Every Value is a Transaction.
+func (c *Client) IterateTransactions(ctx context.Context, accountID string, opts *ListOptions) *Iterator {
+	return c.newIterator(ctx, withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "transactions"), opts), func(data []byte) (interface{}, error) {
+		transaction := Transaction{}
+		err := json.Unmarshal(data, &transaction)
+		return transaction, err
+	})
+}
+
+// ListAllTransactions Lists all account’s transactions, fetching every page.
+func (c *Client) ListAllTransactions(ctx context.Context, accountID string, opts *ListOptions) (*[]Transaction, error) {
+	transactions := &[]Transaction{}
+
+	it := c.IterateTransactions(ctx, accountID, opts)
+	for it.Next() {
+		*transactions = append(*transactions, it.Value().(Transaction))
+	}
+
+	return transactions, it.Err()
+}
+
 // GetTransaction Show an individual transaction for an account.
+// Related resources listed in expand, e.g. ExpandAll, are embedded in the response.
 // Endpoint: GET /accounts/:account_id/transactions/:transaction_id
-func (c *Client) GetTransaction(ctx context.Context, accountID string, transactionID string) (*Transaction, error) {
+func (c *Client) GetTransaction(ctx context.Context, accountID string, transactionID string, expand ...string) (*Transaction, error) {
 	transaction := &Transaction{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "transactions", transactionID), nil)
+	req, err := c.NewRequest(ctx, "GET", withExpand(fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "transactions", transactionID), expand), nil)
 	if err != nil {
 		return transaction, err
 	}
@@ -42,16 +70,40 @@ func (c *Client) GetTransaction(ctx context.Context, accountID string, transacti
 }
 
 // SendMoney Send funds to a bitcoin address, bitcoin cash address, litecoin address, ethereum address, or email address.
+// When sendData.Idem is empty a random one is generated, so that retries and two factor re-issues cannot send twice.
+// To re-issue a send failed with ErrTwoFactorRequired manually, set Idem and pass the token through WithTwoFactorToken.
+// Malformed addresses fail with a *validation.Error, unless SkipAddressValidation is set.
+// With StrictAddressBook, it fails with ErrUnknownDestination unless To is a contact of the AddressBook.
 // Endpoint: POST /accounts/:account_id/transactions
 func (c *Client) SendMoney(ctx context.Context, accountID string, sendData SendMoney) (*Transaction, error) {
 	transaction := &Transaction{}
 
+	if err := c.validateDestination(sendData); err != nil {
+		return transaction, err
+	}
+
+	if err := c.checkDestination(ctx, sendData); err != nil {
+		return transaction, err
+	}
+
+	if sendData.Idem == "" {
+		idem, err := newIdem()
+		if err != nil {
+			return transaction, err
+		}
+		sendData.Idem = idem
+	}
+
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "transactions"), sendData)
 	if err != nil {
 		return transaction, err
 	}
 
-	if err = c.SendWithAuth(req, transaction); err != nil {
+	// Sends carrying an idempotency key can be retried without risk of sending twice
+	req = withIdempotency(req)
+
+	action := PolicyAction{Operation: TransactionTypeSend, AccountID: accountID, Amount: Money{Amount: sendData.Amount, Currency: sendData.Currency}, To: sendData.To}
+	if err = c.guard(ctx, action, func() error { return c.SendWithAuth(req, transaction) }); err != nil {
 		return transaction, err
 	}
 
@@ -68,7 +120,8 @@ func (c *Client) TransferMoney(ctx context.Context, accountID string, transferDa
 		return transaction, err
 	}
 
-	if err = c.SendWithAuth(req, transaction); err != nil {
+	action := PolicyAction{Operation: TransactionTypeTransfer, AccountID: accountID, Amount: Money{Amount: transferData.Amount, Currency: transferData.Currency}, To: transferData.To}
+	if err = c.guard(ctx, action, func() error { return c.SendWithAuth(req, transaction) }); err != nil {
 		return transaction, err
 	}
 
@@ -142,3 +195,61 @@ func (c *Client) CancelRequestMoney(ctx context.Context, accountID string, trans
 
 	return transaction, nil
 }
+
+// Final reports whether the status can no longer change.
+func (s TransactionStatus) Final() bool {
+	switch s {
+	case TransactionStatusCompleted, TransactionStatusFailed, TransactionStatusExpired, TransactionStatusCanceled:
+		return true
+	}
+
+	return false
+}
+
+// Kind returns which kind of party it is: PartyUser, PartyAccount, PartyEmail, PartyAddress,
+// or the raw resource name when unknown.
+func (p *TransactionParty) Kind() string {
+	switch {
+	case p.Resource == "user":
+		return PartyUser
+	case p.Resource == "account":
+		return PartyAccount
+	case p.Resource == "email" || (p.Resource == "" && p.Email != ""):
+		return PartyEmail
+	case strings.HasSuffix(p.Resource, "address") || (p.Resource == "" && p.Address != ""):
+		return PartyAddress
+	}
+
+	return p.Resource
+}
+
+// String returns the most meaningful identifier of the party: email, address, name or ID
+func (p *TransactionParty) String() string {
+	switch {
+	case p.Email != "":
+		return p.Email
+	case p.Address != "":
+		return p.Address
+	case p.AddressInfo != nil && p.AddressInfo.Address != "":
+		return p.AddressInfo.Address
+	case p.Name != "":
+		return p.Name
+	}
+
+	return p.ID
+}
+
+// validateDestination checks offline the address and destination tag of a send.
+// Emails, and currencies or networks the validation package does not know, are left to the API.
+func (c *Client) validateDestination(sendData SendMoney) error {
+	if c.SkipAddressValidation || strings.Contains(sendData.To, "@") {
+		return nil
+	}
+
+	err := validation.ValidateDestination(sendData.Currency, sendData.Network, sendData.To, sendData.DestinationTag)
+	if errors.Is(err, validation.ErrUnsupportedCurrency) || errors.Is(err, validation.ErrUnsupportedNetwork) {
+		return nil
+	}
+
+	return err
+}
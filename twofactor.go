@@ -0,0 +1,47 @@
+package coinbase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// twoFactorKey carries the two factor token of a single call
+type twoFactorKey struct{}
+
+// TwoFactorProvider returns the two factor authentication token for a request
+// that failed with two_factor_required, e.g. by prompting the user.
+type TwoFactorProvider func(ctx context.Context, req *http.Request) (string, error)
+
+// WithTwoFactorToken returns a context that sends token as CB-2FA-TOKEN header with the request.
+// Use it to re-issue a call that failed with ErrTwoFactorRequired, passing the same Idem for sends.
+func WithTwoFactorToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, twoFactorKey{}, token)
+}
+
+// twoFactorToken returns the token set through WithTwoFactorToken
+func twoFactorToken(ctx context.Context) string {
+	token, _ := ctx.Value(twoFactorKey{}).(string)
+	return token
+}
+
+// needsTwoFactor reports whether the request failed with err should be re-issued with a token from TwoFactorProvider.
+// Requests already carrying a token are not re-issued, and the provider is asked at most once per call.
+func (c *Client) needsTwoFactor(req *http.Request, err error) bool {
+	return c.TwoFactorProvider != nil && req.Header.Get("CB-2FA-TOKEN") == "" && errors.Is(err, ErrTwoFactorRequired)
+}
+
+// newIdem generates a random idempotency key (UUID v4)
+func newIdem() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
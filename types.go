@@ -1,6 +1,7 @@
 package coinbase
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,8 +12,71 @@ const (
 	APIBase = "https://api.coinbase.com/v2"
 )
 
+// Transaction types
+const (
+	TransactionTypeSend               TransactionType = "send"
+	TransactionTypeRequest            TransactionType = "request"
+	TransactionTypeTransfer           TransactionType = "transfer"
+	TransactionTypeBuy                TransactionType = "buy"
+	TransactionTypeSell               TransactionType = "sell"
+	TransactionTypeFiatDeposit        TransactionType = "fiat_deposit"
+	TransactionTypeFiatWithdrawal     TransactionType = "fiat_withdrawal"
+	TransactionTypeExchangeDeposit    TransactionType = "exchange_deposit"
+	TransactionTypeExchangeWithdrawal TransactionType = "exchange_withdrawal"
+	TransactionTypeVaultWithdrawal    TransactionType = "vault_withdrawal"
+	TransactionTypeAdvancedTradeFill  TransactionType = "advanced_trade_fill"
+	TransactionTypeStakingReward      TransactionType = "staking_reward"
+	TransactionTypeInterest           TransactionType = "interest"
+	TransactionTypeInflationReward    TransactionType = "inflation_reward"
+	TransactionTypeTrade              TransactionType = "trade"
+	TransactionTypeProDeposit         TransactionType = "pro_deposit"
+	TransactionTypeProWithdrawal      TransactionType = "pro_withdrawal"
+)
+
+// Transaction statuses
+const (
+	TransactionStatusPending             TransactionStatus = "pending"
+	TransactionStatusCompleted           TransactionStatus = "completed"
+	TransactionStatusFailed              TransactionStatus = "failed"
+	TransactionStatusExpired             TransactionStatus = "expired"
+	TransactionStatusCanceled            TransactionStatus = "canceled"
+	TransactionStatusWaitingForSignature TransactionStatus = "waiting_for_signature"
+	TransactionStatusWaitingForClearing  TransactionStatus = "waiting_for_clearing"
+)
+
+// Kinds of transaction parties
+const (
+	PartyUser    = "user"
+	PartyAccount = "account"
+	PartyEmail   = "email"
+	PartyAddress = "address"
+)
+
 type (
 
+	// AddressBookStore stores the contacts of an address book, implementations must be safe for concurrent use
+	AddressBookStore interface {
+		List(ctx context.Context) ([]Contact, error)
+		Get(ctx context.Context, name string) (*Contact, error)	// Fails with ErrContactNotFound
+		Put(ctx context.Context, contact Contact) error
+		Delete(ctx context.Context, name string) error
+	}
+
+	// AddressInfo represents a cryptocurrency address with its optional destination tag or memo
+	AddressInfo struct {
+		Address				string			`json:"address,omitempty"`
+		DestinationTag		string			`json:"destination_tag,omitempty"`
+	}
+
+	// AdvancedTradeFill represents the fill of an Advanced Trade order
+	AdvancedTradeFill struct {
+		FillPrice			*Decimal		`json:"fill_price,omitempty"`
+		ProductID			string			`json:"product_id,omitempty"`
+		OrderID				string			`json:"order_id,omitempty"`
+		Commission			*Decimal		`json:"commission,omitempty"`
+		OrderSide			string			`json:"order_side,omitempty"`
+	}
+
 	Address struct {
 		ID					string			`json:"id,omitempty"`
 		Address				string			`json:"address,omitempty"`
@@ -30,42 +94,33 @@ type (
 		Primary				bool			`json:"primary,omitempty"`
 		Type				string			`json:"type,omitempty"`
 		Currency			string			`json:"currency,omitempty"`
-		Balance				string			`json:"balance,omitempty"`
+		Balance				Money			`json:"balance,omitempty"`
 		CreatedAt			time.Time		`json:"created_at,omitempty"`
 		UpdatedAt			time.Time		`json:"updated_at,omitempty"`
 		Resource			string			`json:"resource,omitempty"`
 		ResourcePath		string			`json:"resource_path,omitempty"`
 	}
 
+	// APIKeyAuth authenticates requests signing them with an API Key and Secret
+	APIKeyAuth struct {
+		Key					string
+		Secret				string
+	}
+
+	// Authenticator applies authentication to a request before it is sent, now is the current time
+	Authenticator interface {
+		Authenticate(req *http.Request, now time.Time) error
+	}
+
 	Buy struct {
 		ID					string			`json:"id,omitempty"`
 		Status				string			`json:"status,omitempty"`
-		PaymentMethod struct {
-			ID				string			`json:"id,omitempty"`
-			Resource		string			`json:"resource,omitempty"`
-			ResourcePath	string			`json:"resource_path,omitempty"`
-		}									`json:"payment_method,omitempty"`
-		Transaction struct {
-			ID				string			`json:"id,omitempty"`
-			Resource		string			`json:"resource,omitempty"`
-			ResourcePath	string			`json:"resource_path,omitempty"`
-		}									`json:"transaction,omitempty"`
-		Amount struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"amount,omitempty"`
-		Total struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"total,omitempty"`
-		SubTotal struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"subtotal,omitempty"`
-		Fee struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"fee,omitempty"`
+		PaymentMethod		PaymentMethodRef	`json:"payment_method,omitempty"`
+		Transaction			TransactionRef		`json:"transaction,omitempty"`
+		Amount				Money			`json:"amount,omitempty"`
+		Total				Money			`json:"total,omitempty"`
+		SubTotal			Money			`json:"subtotal,omitempty"`
+		Fee					Money			`json:"fee,omitempty"`
 		CreatedAt			time.Time		`json:"created_at,omitempty"`
 		UpdatedAt			time.Time		`json:"updated_at,omitempty"`
 		PayoutAt			time.Time		`json:"payout_at,omitempty"`
@@ -83,43 +138,68 @@ type (
 		APIBase              string
 		Log                  io.Writer 	// If set, all request will be logged there
 		Localization		 string		// Preferred language for Error Messages
+		RetryPolicy			 *RetryPolicy	// If set, failed requests will be retried according to it
+		AuthLimiter			 *RateLimiter	// If set, throttles authenticated requests
+		PublicLimiter		 *RateLimiter	// If set, throttles public requests (prices, currencies, exchange rates...)
+		TwoFactorProvider	 TwoFactorProvider	// If set, asked for a token when a request fails with two_factor_required
+		Authenticator		 Authenticator	// If set, used instead of APIKey and APISecret to authenticate requests
+		ClockSyncInterval	 time.Duration	// If set, the offset from the server clock is measured on first use and every interval
+		ResourceCache		 *ResourceCache	// If set, resources fetched by Resolve are cached there
+		Policy				 *Policy		// If set, money-moving calls are checked against it before being sent
+		AddressBook			 AddressBookStore	// If set, contacts SendToContact sends to
+		StrictAddressBook	 bool			// If set, SendMoney refuses destinations not in AddressBook
+		SkipAddressValidation bool			// If set, SendMoney does not validate destination addresses offline
+		Clock				 Clock			// Local clock, defaults to the system clock
+		clock				 clockOffset
+	}
+
+	// Contact is a named destination of the address book
+	Contact struct {
+		Name				string			`json:"name"`
+		Currency			string			`json:"currency"`
+		Network				string			`json:"network,omitempty"`			// e.g. ethereum or base, empty for the default network of the currency
+		Address				string			`json:"address"`						// Cryptocurrency address or email
+		DestinationTag		string			`json:"destination_tag,omitempty"`	// Destination tag or memo, required by some exchanges
+		Note				string			`json:"note,omitempty"`
 	}
 
 	CreateAddress struct {
 		Name				string				  `json:"name,omitempty"`
 	}
 
+	// CurrencyPair represents a base and a quote currency, e.g. BTC-USD
+	CurrencyPair string
+
+	// Clock provides the current time and timers, it can be replaced in tests
+	Clock interface {
+		Now() time.Time
+		After(d time.Duration) <-chan time.Time
+	}
+
 	Currency struct {
 		ID					string				  `json:"id,omitempty"`
 		Name				string				  `json:"name,omitempty"`
 		MinSize				string				  `json:"min_size,omitempty"`
 	}
 
+	// Execution reports the outcome of a recurring buy for one slot of its schedule
+	Execution struct {
+		RecurringBuyID		string
+		Slot				time.Time		// Scheduled time of the execution
+		Quote				*Quote			// Quote obtained before committing, nil if quoting failed
+		Buy					*Buy			// Committed buy, nil if skipped or failed
+		Skipped				bool			// True when the price was above MaxPrice
+		Err					error
+	}
+
 	Deposit struct {
 		ID					string			`json:"id,omitempty"`
 		Status				string			`json:"status,omitempty"`
-		PaymentMethod struct {
-			ID				string			`json:"id,omitempty"`
-			Resource		string			`json:"resource,omitempty"`
-			ResourcePath	string			`json:"resource_path,omitempty"`
-		}									`json:"payment_method,omitempty"`
-		Transaction struct {
-			ID				string			`json:"id,omitempty"`
-			Resource		string			`json:"resource,omitempty"`
-			ResourcePath	string			`json:"resource_path,omitempty"`
-		}									`json:"transaction,omitempty"`
-		Amount struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"amount,omitempty"`
-		SubTotal struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"subtotal,omitempty"`
-		Fee struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"fee,omitempty"`
+		PaymentMethod		PaymentMethodRef	`json:"payment_method,omitempty"`
+		Transaction			TransactionRef		`json:"transaction,omitempty"`
+		Amount				Money			`json:"amount,omitempty"`
+		SubTotal			Money			`json:"subtotal,omitempty"`
+		Fee					Money			`json:"fee,omitempty"`
 		CreatedAt			time.Time		`json:"created_at,omitempty"`
 		UpdatedAt			time.Time		`json:"updated_at,omitempty"`
 		PayoutAt			time.Time		`json:"payout_at,omitempty"`
@@ -129,15 +209,18 @@ type (
 	}
 
 	DepositFunds struct {
-		Amount							string			`json:"amount,omitempty"`
+		Amount							*Decimal		`json:"amount,omitempty"`
 		Currency						string			`json:"currency,omitempty"`
 		PaymentMethod					string			`json:"payment_method,omitempty"`
-		Commit							bool			`json:"commit,omitempty"`
+		Commit							*bool			`json:"commit,omitempty"`		// The API commits when nil, use Bool(false) to commit later
 	}
 
 	// ErrorResponse represents a Coinbase REST API Error Response
 	ErrorResponse struct {
-		Response        	*http.Response        `json:"-,omitempty"`
+		Response        	*http.Response        `json:"-"`
+		StatusCode			int					  `json:"-"`		// HTTP status code
+		RequestID			string				  `json:"-"`		// Request ID header, useful when contacting Coinbase support
+		Body				[]byte				  `json:"-"`		// Raw response body
 		Errors          	[]Errors              `json:"errors,omitempty"`
 	}
 
@@ -150,17 +233,46 @@ type (
 
 	ExchangeRates struct {
 		Currency 			string				  `json:"currency,omitempty"`
-		Rates				map[string]string	  `json:"rate,omitempty"`
-	}
-
-	From struct {
-		ID					string				  `json:"id,omitempty"`
-		Resource			string				  `json:",omitempty"`
+		Rates				map[string]string	  `json:"rates,omitempty"`
 	}
 
 	Network struct {
 		Status				string				  `json:"status,omitempty"`
+		StatusDescription	string				  `json:"status_description,omitempty"`
 		Name				string                `json:"name,omitempty"`
+		Hash				string				  `json:"hash,omitempty"`
+		TransactionURL		string				  `json:"transaction_url,omitempty"`
+		TransactionFee		*Money				  `json:"transaction_fee,omitempty"`
+		Confirmations		int					  `json:"confirmations,omitempty"`
+	}
+
+	// OAuth2Config describes a Coinbase Connect OAuth2 application
+	OAuth2Config struct {
+		ClientID						string
+		ClientSecret					string
+		RedirectURL						string
+		Scopes							[]string		// Requested permissions, e.g. wallet:accounts:read
+		AuthURL							string			// Defaults to OAuth2AuthURL
+		TokenURL						string			// Defaults to OAuth2TokenURL
+		RevokeURL						string			// Defaults to OAuth2RevokeURL
+		HTTPClient						*http.Client	// Defaults to http.DefaultClient
+	}
+
+	// OAuth2Error represents an error returned by the OAuth2 endpoints
+	OAuth2Error struct {
+		StatusCode						int				`json:"-"`
+		Code							string			`json:"error,omitempty"`
+		Description						string			`json:"error_description,omitempty"`
+	}
+
+	// OAuth2Token represents the tokens issued to an OAuth2 application
+	OAuth2Token struct {
+		AccessToken						string			`json:"access_token,omitempty"`
+		TokenType						string			`json:"token_type,omitempty"`
+		RefreshToken					string			`json:"refresh_token,omitempty"`
+		ExpiresIn						int64			`json:"expires_in,omitempty"`
+		Scope							string			`json:"scope,omitempty"`
+		Expiry							time.Time		`json:"expiry,omitempty"`
 	}
 
 	Pagination struct {
@@ -172,16 +284,38 @@ type (
 		NextUri				string				 `json:"next_uri,omitempty"`
 	}
 
+	// ListOptions represents the pagination parameters accepted by every list endpoint
+	ListOptions struct {
+		Limit							int				// Number of results per call, between 1 and 100 (default 25)
+		Order							string			// Result order, "desc" (default) or "asc"
+		StartingAfter					string			// Cursor, return results after this resource ID
+		EndingBefore					string			// Cursor, return results before this resource ID
+		Expand							[]string		// Related resources to embed in the results, e.g. ExpandAll
+	}
+
+	// PaymentURIOptions are the optional fields of a payment URI
+	PaymentURIOptions struct {
+		Amount							*Decimal		// Amount requested, in the currency of the network
+		Label							string			// Name of the recipient
+		Message							string			// Description of the payment
+	}
+
 	PlaceBuy struct {
-		Amount							string			`json:"amount,omitempty"`
-		Total							string			`json:"total,omitempty"`
+		Amount							*Decimal		`json:"amount,omitempty"`
+		Total							*Decimal		`json:"total,omitempty"`
 		Currency						string			`json:"currency,omitempty"`
 		PaymentMethod					string			`json:"payment_method,omitempty"`
 		AgreeBtcAmountVaries			bool			`json:"agree_btc_amount_varies,omitempty"`
-		Commit							bool			`json:"commit,omitempty"`
+		Commit							*bool			`json:"commit,omitempty"`		// The API commits when nil, use Bool(false) to commit later
 		Quote							bool			`json:"quote,omitempty"`
 	}
 
+	// Money represents an amount of a currency, as encoded by the API
+	Money struct {
+		Amount							*Decimal		`json:"amount,omitempty"`
+		Currency						string			`json:"currency,omitempty"`
+	}
+
 	PaymentMethod struct {
 		ID								string			`json:"id,omitempty"`
 		Type							string			`json:"type,omitempty"`
@@ -200,62 +334,143 @@ type (
 	}
 
 	PlaceSell struct {
-		Amount							string			`json:"amount,omitempty"`
-		Total							string			`json:"total,omitempty"`
+		Amount							*Decimal		`json:"amount,omitempty"`
+		Total							*Decimal		`json:"total,omitempty"`
 		Currency						string			`json:"currency,omitempty"`
 		PaymentMethod					string			`json:"payment_method,omitempty"`
 		AgreeBtcAmountVaries			bool			`json:"agree_btc_amount_varies,omitempty"`
-		Commit							bool			`json:"commit,omitempty"`
+		Commit							*bool			`json:"commit,omitempty"`		// The API commits when nil, use Bool(false) to commit later
 		Quote							bool			`json:"quote,omitempty"`
 	}
 
+	// PolicyAction describes a money-moving call checked by a Policy
+	PolicyAction struct {
+		Operation						TransactionType	// TransactionTypeSend, TransactionTypeTransfer, TransactionTypeBuy...
+		AccountID						string
+		Amount							Money
+		To								string			// Destination address, email or account ID, for sends and transfers
+	}
+
+	// PolicyLimit caps the amounts moved in a currency, valued in the native currency of the Policy
+	PolicyLimit struct {
+		PerTransaction					*Decimal		// If set, maximum of a single call
+		Daily							*Decimal		// If set, maximum over the last 24 hours
+		Weekly							*Decimal		// If set, maximum over the last 7 days
+	}
+
+	// PolicyViolation is returned when a money-moving call is refused by the Policy, it matches ErrPolicyViolation
+	PolicyViolation struct {
+		Rule							string			// PolicyRulePerTransaction, PolicyRuleDaily, PolicyRuleWeekly...
+		Action							PolicyAction
+		Reason							string
+	}
+
+	// PolicyWindow is a time range in which money can be moved, e.g. From: 9 * time.Hour, To: 17 * time.Hour
+	PolicyWindow struct {
+		Days							[]time.Weekday	// If set, the window only applies on these days
+		From							time.Duration	// Offset since midnight, inclusive
+		To								time.Duration	// Offset since midnight, exclusive. If not after From, the window ends the next day
+	}
+
 	Price struct {
-		Amount							string			`json:"amount,omitempty"`
+		Base							string			`json:"base,omitempty"`
+		Amount							*Decimal		`json:"amount,omitempty"`
 		Currency						string			`json:"currency,omitempty"`
 	}
 
+	// ResourceRef represents a reference to another resource
+	ResourceRef struct {
+		ID								string			`json:"id,omitempty"`
+		Resource						string			`json:"resource,omitempty"`
+		ResourcePath					string			`json:"resource_path,omitempty"`
+	}
+
+	// BuyRef represents a reference to a buy, Expanded is set when the buy was expanded
+	BuyRef struct {
+		ResourceRef
+		Expanded						*Buy			`json:"-"`
+	}
+
+	// PaymentMethodRef represents a reference to a payment method, Expanded is set when the payment method was expanded
+	PaymentMethodRef struct {
+		ResourceRef
+		Expanded						*PaymentMethod	`json:"-"`
+	}
+
+	// SellRef represents a reference to a sell, Expanded is set when the sell was expanded
+	SellRef struct {
+		ResourceRef
+		Expanded						*Sell			`json:"-"`
+	}
+
+	// TransactionRef represents a reference to a transaction, Expanded is set when the transaction was expanded
+	TransactionRef struct {
+		ResourceRef
+		Expanded						*Transaction	`json:"-"`
+	}
+
+	// QuoteOptions control the commit guards of a Quote
+	QuoteOptions struct {
+		TTL								time.Duration	// How long the quote can be committed, defaults to DefaultQuoteTTL
+		MaxSlippage						*Decimal		// If set, maximum relative price move against a fresh quote, e.g. 0.01 for 1%
+	}
+
+	// RunStore persists which schedule slots were executed, implementations must be safe for concurrent use
+	RunStore interface {
+		// Claim records slot as executed for the recurring buy id, it returns false if it was already claimed
+		Claim(ctx context.Context, id string, slot time.Time) (bool, error)
+		// Last returns the last claimed slot of the recurring buy id, zero if none
+		Last(ctx context.Context, id string) (time.Time, error)
+	}
+
 	Response struct {
 		Pagination			interface{}			  `json:"pagination"`
 		Data				interface{}			  `json:"data"`
 	}
 
+	// RecurringBuy describes a buy repeated on a schedule (dollar-cost averaging), set either Amount or Total
+	RecurringBuy struct {
+		ID								string			// Unique identifier, run state is stored under it
+		Schedule						Schedule
+		AccountID						string
+		PaymentMethod					string
+		Currency						string
+		Amount							*Decimal		// Amount of cryptocurrency to buy
+		Total							*Decimal		// Amount of fiat to spend, including fees
+		MaxPrice						*Decimal		// If set, slots are skipped when the unit price is above it
+	}
+
 	RequestMoney struct {
 		Type							string			`json:"type,omitempty"`
 		To								string			`json:"to,omitempty"`
-		Amount							string			`json:"amount,omitempty"`
+		Amount							*Decimal		`json:"amount,omitempty"`
 		Currency						string			`json:"currency,omitempty"`
 		Description						string			`json:"description,omitempty"`
 	}
 
+	// RetryPolicy controls how requests failed with transient errors (429, 5xx, connection errors) are retried.
+	// Only idempotent requests are retried, POST requests are retried only when they carry an idempotency key.
+	RetryPolicy struct {
+		MaxAttempts						int				// Maximum number of attempts, including the first one
+		BaseDelay						time.Duration	// Delay before the first retry, doubled on every attempt
+		MaxDelay						time.Duration	// Upper bound of the delay between attempts, Retry-After and X-RateLimit-Reset included
+		RetryNonIdempotent				bool			// Retry also POST requests without idempotency key. Unsafe for money-moving calls
+	}
+
+	// Schedule returns the first time strictly after t at which a job runs, zero if it never runs again
+	Schedule interface {
+		Next(t time.Time) time.Time
+	}
+
 	Sell struct {
 		ID					string			`json:"id,omitempty"`
 		Status				string			`json:"status,omitempty"`
-		PaymentMethod struct {
-			ID				string			`json:"id,omitempty"`
-			Resource		string			`json:"resource,omitempty"`
-			ResourcePath	string			`json:"resource_path,omitempty"`
-		}									`json:"payment_method,omitempty"`
-		Transaction struct {
-			ID				string			`json:"id,omitempty"`
-			Resource		string			`json:"resource,omitempty"`
-			ResourcePath	string			`json:"resource_path,omitempty"`
-		}									`json:"transaction,omitempty"`
-		Amount struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"amount,omitempty"`
-		Total struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"total,omitempty"`
-		SubTotal struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"subtotal,omitempty"`
-		Fee struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"fee,omitempty"`
+		PaymentMethod		PaymentMethodRef	`json:"payment_method,omitempty"`
+		Transaction			TransactionRef		`json:"transaction,omitempty"`
+		Amount				Money			`json:"amount,omitempty"`
+		Total				Money			`json:"total,omitempty"`
+		SubTotal			Money			`json:"subtotal,omitempty"`
+		Fee					Money			`json:"fee,omitempty"`
 		CreatedAt			time.Time		`json:"created_at,omitempty"`
 		UpdatedAt			time.Time		`json:"updated_at,omitempty"`
 		PayoutAt			time.Time		`json:"payout_at,omitempty"`
@@ -268,14 +483,41 @@ type (
 	SendMoney struct {
 		Type							string			`json:"type,omitempty"`
 		To								string			`json:"to,omitempty"`
-		Amount							string			`json:"amount,omitempty"`
+		Amount							*Decimal		`json:"amount,omitempty"`
 		Currency						string			`json:"currency,omitempty"`
 		Description						string			`json:"description,omitempty"`
 		SkipNotifications				bool			`json:"skip_notifications,omitempty"`
-		Fee								string			`json:"fee,omitempty"`
+		Fee								*Decimal		`json:"fee,omitempty"`
 		Idem							string			`json:"idem,omitempty"`
 		ToFinancialInstitution			bool			`json:"to_financial_institution,omitempty"`
 		FinancialInstitutionWebsite		string			`json:"financial_institution_website,omitempty"`
+		Network							string			`json:"network,omitempty"`
+		DestinationTag					string			`json:"destination_tag,omitempty"`
+	}
+
+	// SyncEvent is emitted by a Syncer for a new transaction or a status change of a tracked one
+	SyncEvent struct {
+		Type							SyncEventType
+		AccountID						string
+		Transaction						*Transaction
+		PreviousStatus					TransactionStatus	// Set for SyncEventStatusChanged
+	}
+
+	// SyncEventType is the kind of a SyncEvent
+	SyncEventType string
+
+	// SyncState is the sync progress of an account
+	SyncState struct {
+		Cursor							string							`json:"cursor,omitempty"`	// ID of the newest transaction seen
+		Pending							map[string]TransactionStatus	`json:"pending,omitempty"`	// Last seen status of transactions not final yet, by ID
+	}
+
+	// SyncStore persists the SyncState of each account, implementations must be safe for concurrent use
+	SyncStore interface {
+		// Load returns the state of an account, empty if it was never synced
+		Load(ctx context.Context, accountID string) (SyncState, error)
+		// Save stores the state of an account
+		Save(ctx context.Context, accountID string, state SyncState) error
 	}
 
 	Time struct {
@@ -284,30 +526,77 @@ type (
 	}
 
 	Transaction struct {
-		ID					string			`json:"id,omitempty"`
-		Type				string			`json:"type,omitempty"`
-		Status				string			`json:"status,omitempty"`
-		Amount struct {
-			Amount				string		`json:"amount,omitempty"`
-			Currency			string		`json:"currency,omitempty"`
-		}									`json:"amount,omitempty"`
-		NativeAmount struct {
-			Amount				string		`json:"amount,omitempty"`
-			Currency			string		`json:"currency,omitempty"`
-		}									`json:"native_amount,omitempty"`
-		Description			string			`json:"description,omitempty"`
-		CreatedAt			time.Time		`json:"created_at,omitempty"`
-		UpdatedAt			time.Time		`json:"updated_at,omitempty"`
-		Resource			string			`json:"resource,omitempty"`
-		ResourcePath		string			`json:"resource_path,omitempty"`
-		Network				Network			`json:"network,omitempty"`
-		From				From			`json:"from,omitempty"`
+		ID					string				`json:"id,omitempty"`
+		Type				TransactionType		`json:"type,omitempty"`
+		Status				TransactionStatus	`json:"status,omitempty"`
+		Amount				Money				`json:"amount,omitempty"`
+		NativeAmount		Money				`json:"native_amount,omitempty"`
+		Description			string				`json:"description,omitempty"`
+		CreatedAt			time.Time			`json:"created_at,omitempty"`
+		UpdatedAt			time.Time			`json:"updated_at,omitempty"`
+		Resource			string				`json:"resource,omitempty"`
+		ResourcePath		string				`json:"resource_path,omitempty"`
+		InstantExchange		bool				`json:"instant_exchange,omitempty"`
+		Idem				string				`json:"idem,omitempty"`
+		Network				*Network			`json:"network,omitempty"`
+		To					*TransactionParty	`json:"to,omitempty"`
+		From				*TransactionParty	`json:"from,omitempty"`
+		Details				*TransactionDetails	`json:"details,omitempty"`
+		Buy					*BuyRef				`json:"buy,omitempty"`
+		Sell				*SellRef			`json:"sell,omitempty"`
+		Trade				*ResourceRef		`json:"trade,omitempty"`
+		Address				*ResourceRef		`json:"address,omitempty"`
+		Application			*ResourceRef		`json:"application,omitempty"`
+		FiatDeposit			*ResourceRef		`json:"fiat_deposit,omitempty"`
+		FiatWithdrawal		*ResourceRef		`json:"fiat_withdrawal,omitempty"`
+		AdvancedTradeFill	*AdvancedTradeFill	`json:"advanced_trade_fill,omitempty"`
+	}
+
+	// TransactionDetails represents the human readable summary of a transaction
+	TransactionDetails struct {
+		Title				string				`json:"title,omitempty"`
+		Subtitle			string				`json:"subtitle,omitempty"`
+		Header				string				`json:"header,omitempty"`
+		Health				string				`json:"health,omitempty"`
+		PaymentMethodName	string				`json:"payment_method_name,omitempty"`
+	}
+
+	// TransactionParty represents the sender or the recipient of a transaction:
+	// a user, an account, an email address or a cryptocurrency address. Kind tells which one.
+	TransactionParty struct {
+		ID					string				`json:"id,omitempty"`
+		Resource			string				`json:"resource,omitempty"`
+		ResourcePath		string				`json:"resource_path,omitempty"`
+		Email				string				`json:"email,omitempty"`
+		Address				string				`json:"address,omitempty"`
+		Currency			string				`json:"currency,omitempty"`
+		Name				string				`json:"name,omitempty"`
+		Username			string				`json:"username,omitempty"`
+		AddressInfo			*AddressInfo		`json:"address_info,omitempty"`
+		User				*User				`json:"-"`	// Set when the party is an expanded user
+		Account				*Account			`json:"-"`	// Set when the party is an expanded account
+	}
+
+	// TransactionStatus represents the status of a transaction
+	TransactionStatus string
+
+	// TransactionType represents the type of a transaction
+	TransactionType string
+
+	// Trade is implemented by Buy, Sell, Deposit and Withdrawal
+	Trade interface {
+		GetID() string
+		GetStatus() string
+		GetAmount() Money
+		GetSubTotal() Money
+		GetFee() Money
+		GetTotal() Money
 	}
 
 	TransferMoney struct {
 		Type							string			`json:"type,omitempty"`
 		To								string			`json:"to,omitempty"`
-		Amount							string			`json:"amount,omitempty"`
+		Amount							*Decimal		`json:"amount,omitempty"`
 		Currency						string			`json:"currency,omitempty"`
 		Description						string			`json:"description,omitempty"`
 	}
@@ -339,28 +628,11 @@ type (
 		ID					string			`json:"id,omitempty"`
 		Type				string			`json:"type,omitempty"`
 		Status				string			`json:"status,omitempty"`
-		Amount struct {
-			Amount				string		`json:"amount,omitempty"`
-			Currency			string		`json:"currency,omitempty"`
-		}									`json:"amount,omitempty"`
-		PaymentMethod struct {
-			ID				string			`json:"id,omitempty"`
-			Resource		string			`json:"resource,omitempty"`
-			ResourcePath	string			`json:"resource_path,omitempty"`
-		}									`json:"payment_method,omitempty"`
-		Transaction struct {
-			ID				string			`json:"id,omitempty"`
-			Resource		string			`json:"resource,omitempty"`
-			ResourcePath	string			`json:"resource_path,omitempty"`
-		}									`json:"transaction,omitempty"`
-		SubTotal struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"subtotal,omitempty"`
-		Fee struct {
-			Amount			string			`json:"amount,omitempty"`
-			Currency		string			`json:"currency,omitempty"`
-		}									`json:"fee,omitempty"`
+		Amount				Money			`json:"amount,omitempty"`
+		PaymentMethod		PaymentMethodRef	`json:"payment_method,omitempty"`
+		Transaction			TransactionRef		`json:"transaction,omitempty"`
+		SubTotal			Money			`json:"subtotal,omitempty"`
+		Fee					Money			`json:"fee,omitempty"`
 		CreatedAt			time.Time		`json:"created_at,omitempty"`
 		UpdatedAt			time.Time		`json:"updated_at,omitempty"`
 		PayoutAt			time.Time		`json:"payout_at,omitempty"`
@@ -370,7 +642,7 @@ type (
 	}
 
 	Withdraw struct {
-		Amount							string			`json:"amount,omitempty"`
+		Amount							*Decimal		`json:"amount,omitempty"`
 		Currency						string			`json:"currency,omitempty"`
 		PaymentMethod					string			`json:"payment_method,omitempty"`
 	}
@@ -378,5 +650,8 @@ type (
 
 // Error method implementation for ErrorResponse struct
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %v", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Errors)
+	if r.Response == nil || r.Response.Request == nil {
+		return fmt.Sprintf("%d %v", r.StatusCode, r.Errors)
+	}
+	return fmt.Sprintf("%v %v: %d %v", r.Response.Request.Method, r.Response.Request.URL, r.StatusCode, r.Errors)
 }
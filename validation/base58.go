@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/big"
+	"strings"
+)
+
+// Base58 alphabets
+const (
+	bitcoinAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	rippleAlphabet  = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+)
+
+// base58Decode decodes s, every leading zero character is a leading zero byte
+func base58Decode(s string, alphabet string) ([]byte, error) {
+	if s == "" {
+		return nil, ErrInvalidFormat
+	}
+
+	n := new(big.Int)
+	radix := big.NewInt(58)
+
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(alphabet, s[i])
+		if digit < 0 {
+			return nil, ErrInvalidFormat
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// base58CheckDecode decodes s and verifies its double SHA-256 checksum, it returns the payload with the version bytes
+func base58CheckDecode(s string, alphabet string) ([]byte, error) {
+	decoded, err := base58Decode(s, alphabet)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(decoded) < 5 {
+		return nil, ErrInvalidLength
+	}
+
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], checksum) {
+		return nil, ErrInvalidChecksum
+	}
+
+	return payload, nil
+}
+
+// validateLegacy validates a Base58Check address made of a version byte and a 20 bytes hash
+func validateLegacy(address string, versions ...byte) error {
+	payload, err := base58CheckDecode(address, bitcoinAlphabet)
+	if err != nil {
+		return err
+	}
+
+	if len(payload) != 21 {
+		return ErrInvalidLength
+	}
+
+	for _, version := range versions {
+		if payload[0] == version {
+			return nil
+		}
+	}
+
+	return ErrInvalidVersion
+}
+
+// validateBitcoin validates a P2PKH, P2SH, segwit or taproot bitcoin address
+func validateBitcoin(address string) error {
+	switch {
+	case hasBech32Prefix(address, "bc"):
+		return validateSegwit(address, "bc")
+	case hasBech32Prefix(address, "tb"), hasBech32Prefix(address, "bcrt"):
+		return ErrInvalidVersion
+	}
+
+	return validateLegacy(address, 0x00, 0x05)
+}
+
+// validateLitecoin validates a legacy or segwit litecoin address
+func validateLitecoin(address string) error {
+	if hasBech32Prefix(address, "ltc") {
+		return validateSegwit(address, "ltc")
+	}
+
+	return validateLegacy(address, 0x30, 0x32, 0x05)
+}
+
+// validateDogecoin validates a dogecoin address
+func validateDogecoin(address string) error {
+	return validateLegacy(address, 0x1e, 0x16)
+}
+
+// validateBitcoinCash validates a CashAddr or legacy bitcoin cash address
+func validateBitcoinCash(address string) error {
+	if strings.HasPrefix(address, "1") || strings.HasPrefix(address, "3") {
+		return validateLegacy(address, 0x00, 0x05)
+	}
+
+	return validateCashAddr(address)
+}
+
+// validateSolana validates a solana address, the base58 encoding of a 32 bytes public key
+func validateSolana(address string) error {
+	decoded, err := base58Decode(address, bitcoinAlphabet)
+	if err != nil {
+		return err
+	}
+
+	if len(decoded) != 32 {
+		return ErrInvalidLength
+	}
+
+	return nil
+}
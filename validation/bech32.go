@@ -0,0 +1,154 @@
+package validation
+
+import (
+	"strings"
+)
+
+// bech32Charset maps 5 bits values to characters, it is shared by CashAddr
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants of the two encodings
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// hasBech32Prefix reports whether address starts with the human readable part hrp and the separator
+func hasBech32Prefix(address string, hrp string) bool {
+	return strings.HasPrefix(strings.ToLower(address), hrp+"1")
+}
+
+// bech32Polymod computes the BCH checksum of values
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+
+	return chk
+}
+
+// bech32HRPExpand expands the human readable part for the checksum computation
+func bech32HRPExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+
+	return expanded
+}
+
+// bech32Decode decodes a Bech32 or Bech32m string, it returns the human readable part,
+// the 5 bits data without the checksum and the checksum constant of the encoding
+func bech32Decode(s string) (string, []byte, uint32, error) {
+	if len(s) > 90 {
+		return "", nil, 0, ErrInvalidLength
+	}
+
+	lower := strings.ToLower(s)
+	if s != lower && s != strings.ToUpper(s) {
+		return "", nil, 0, ErrInvalidFormat
+	}
+
+	separator := strings.LastIndexByte(lower, '1')
+	if separator < 1 || separator+7 > len(lower) {
+		return "", nil, 0, ErrInvalidFormat
+	}
+
+	hrp := lower[:separator]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, ErrInvalidFormat
+		}
+	}
+
+	data := make([]byte, 0, len(lower)-separator-1)
+	for i := separator + 1; i < len(lower); i++ {
+		v := strings.IndexByte(bech32Charset, lower[i])
+		if v < 0 {
+			return "", nil, 0, ErrInvalidFormat
+		}
+		data = append(data, byte(v))
+	}
+
+	constant := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	if constant != bech32Const && constant != bech32mConst {
+		return "", nil, 0, ErrInvalidChecksum
+	}
+
+	return hrp, data[:len(data)-6], constant, nil
+}
+
+// convertBits regroups bits of values from groups of from bits to groups of to bits.
+// Without padding, the remaining bits must be fewer than from and zero.
+func convertBits(values []byte, from uint, to uint, pad bool) ([]byte, error) {
+	acc, bits := uint32(0), uint(0)
+	max := uint32(1)<<to - 1
+
+	converted := []byte{}
+	for _, v := range values {
+		if uint32(v)>>from != 0 {
+			return nil, ErrInvalidFormat
+		}
+		acc = acc<<from | uint32(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			converted = append(converted, byte(acc>>bits&max))
+		}
+	}
+
+	switch {
+	case pad && bits > 0:
+		converted = append(converted, byte(acc<<(to-bits)&max))
+	case !pad && (bits >= from || acc<<(to-bits)&max != 0):
+		return nil, ErrInvalidFormat
+	}
+
+	return converted, nil
+}
+
+// validateSegwit validates a segwit (Bech32, version 0) or taproot (Bech32m, version 1 and later) address
+func validateSegwit(address string, expectedHRP string) error {
+	hrp, data, constant, err := bech32Decode(address)
+	if err != nil {
+		return err
+	}
+
+	if hrp != expectedHRP {
+		return ErrInvalidVersion
+	}
+
+	if len(data) < 1 || data[0] > 16 {
+		return ErrInvalidVersion
+	}
+
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return err
+	}
+
+	version := data[0]
+	switch {
+	case len(program) < 2 || len(program) > 40:
+		return ErrInvalidLength
+	case version == 0 && len(program) != 20 && len(program) != 32:
+		return ErrInvalidLength
+	case version == 0 && constant != bech32Const, version != 0 && constant != bech32mConst:
+		return ErrInvalidChecksum
+	}
+
+	return nil
+}
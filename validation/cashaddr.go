@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"strings"
+)
+
+// cashAddrPrefix is the prefix of mainnet bitcoin cash addresses, it may be omitted
+const cashAddrPrefix = "bitcoincash"
+
+// cashAddrPolymod computes the 40 bits BCH checksum of CashAddr
+func cashAddrPolymod(values []byte) uint64 {
+	generator := [5]uint64{0x98f2bc8e61, 0x79b76d99e2, 0xf33e5fb3c4, 0xae2eabe2a8, 0x1e4f43e470}
+
+	c := uint64(1)
+	for _, v := range values {
+		top := c >> 35
+		c = (c&0x07ffffffff)<<5 ^ uint64(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				c ^= generator[i]
+			}
+		}
+	}
+
+	return c ^ 1
+}
+
+// validateCashAddr validates a CashAddr bitcoin cash address, with or without its prefix
+func validateCashAddr(address string) error {
+	lower := strings.ToLower(address)
+	if address != lower && address != strings.ToUpper(address) {
+		return ErrInvalidFormat
+	}
+
+	prefix, payload := cashAddrPrefix, lower
+	if i := strings.IndexByte(lower, ':'); i >= 0 {
+		prefix, payload = lower[:i], lower[i+1:]
+	}
+
+	if prefix != cashAddrPrefix {
+		return ErrInvalidVersion
+	}
+
+	// Version byte, a 20 bytes hash at least and the checksum
+	if len(payload) < 42 {
+		return ErrInvalidLength
+	}
+
+	values := make([]byte, 0, len(prefix)+1+len(payload))
+	for i := 0; i < len(prefix); i++ {
+		values = append(values, prefix[i]&31)
+	}
+	values = append(values, 0)
+
+	data := make([]byte, 0, len(payload))
+	for i := 0; i < len(payload); i++ {
+		v := strings.IndexByte(bech32Charset, payload[i])
+		if v < 0 {
+			return ErrInvalidFormat
+		}
+		data = append(data, byte(v))
+	}
+
+	if cashAddrPolymod(append(values, data...)) != 0 {
+		return ErrInvalidChecksum
+	}
+
+	decoded, err := convertBits(data[:len(data)-8], 5, 8, false)
+	if err != nil {
+		return err
+	}
+
+	// The version byte holds the type in bits 3-6, P2PKH or P2SH, and the size of the hash in bits 0-2
+	version := decoded[0]
+	sizes := [8]int{20, 24, 28, 32, 40, 48, 56, 64}
+
+	switch {
+	case version&0x80 != 0 || version>>3 > 1:
+		return ErrInvalidVersion
+	case len(decoded)-1 != sizes[version&7]:
+		return ErrInvalidLength
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+)
+
+// keccakRoundConstants are the iota step constants of Keccak-f[1600]
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakRotations are the rho step offsets, indexed by x + 5y
+var keccakRotations = [25]uint{
+	0, 1, 62, 28, 27,
+	36, 44, 6, 55, 20,
+	3, 10, 43, 25, 39,
+	41, 45, 15, 21, 8,
+	18, 2, 61, 56, 14,
+}
+
+// keccakF1600 applies the Keccak-f[1600] permutation to the state, indexed by x + 5y
+func keccakF1600(a *[25]uint64) {
+	var b [25]uint64
+	var c, d [5]uint64
+
+	for round := 0; round < 24; round++ {
+		// θ
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d[x] = c[(x+4)%5] ^ (c[(x+1)%5]<<1 | c[(x+1)%5]>>63)
+		}
+		for i := 0; i < 25; i++ {
+			a[i] ^= d[i%5]
+		}
+
+		// ρ and π
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				r := keccakRotations[x+5*y]
+				b[y+5*((2*x+3*y)%5)] = a[x+5*y]<<r | a[x+5*y]>>((64-r)%64)
+			}
+		}
+
+		// χ
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				a[x+5*y] = b[x+5*y] ^ (^b[(x+1)%5+5*y] & b[(x+2)%5+5*y])
+			}
+		}
+
+		// ι
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
+
+// keccak256 returns the Keccak-256 hash of data, as used by Ethereum. It differs from SHA3-256 by its padding.
+func keccak256(data []byte) [32]byte {
+	const rate = 136
+
+	var state [25]uint64
+
+	// Pad with 0x01 ... 0x80 to a multiple of the rate
+	padded := make([]byte, len(data), len(data)+rate)
+	copy(padded, data)
+	padded = append(padded, 0x01)
+	for len(padded)%rate != 0 {
+		padded = append(padded, 0)
+	}
+	padded[len(padded)-1] |= 0x80
+
+	for offset := 0; offset < len(padded); offset += rate {
+		for i := 0; i < rate/8; i++ {
+			state[i] ^= binary.LittleEndian.Uint64(padded[offset+8*i:])
+		}
+		keccakF1600(&state)
+	}
+
+	var hash [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(hash[8*i:], state[i])
+	}
+
+	return hash
+}
+
+// validateEIP55 validates an Ethereum address. Addresses in mixed case must match their EIP-55 checksum,
+// all lowercase or all uppercase addresses carry no checksum and are only checked for format.
+func validateEIP55(address string) error {
+	if !strings.HasPrefix(address, "0x") {
+		return ErrInvalidFormat
+	}
+
+	digits := address[2:]
+	if len(digits) != 40 {
+		return ErrInvalidLength
+	}
+
+	lower := strings.ToLower(digits)
+	if _, err := hex.DecodeString(lower); err != nil {
+		return ErrInvalidFormat
+	}
+
+	if digits == lower || digits == strings.ToUpper(digits) {
+		return nil
+	}
+
+	hash := keccak256([]byte(lower))
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < 'A' {
+			continue
+		}
+
+		// A letter is uppercase when the matching nibble of the hash is 8 or more
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+
+		if (nibble >= 8) != (digits[i] <= 'F') {
+			return ErrInvalidChecksum
+		}
+	}
+
+	return nil
+}
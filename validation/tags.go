@@ -0,0 +1,141 @@
+package validation
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"strconv"
+)
+
+// Stellar StrKey version bytes
+const (
+	stellarAccountVersion = 6 << 3
+	stellarMuxedVersion   = 12 << 3
+)
+
+// stellarMaxMemo is the maximum length of a stellar text memo, in bytes
+const stellarMaxMemo = 28
+
+// X-address prefixes of the XRP Ledger mainnet
+var rippleXAddressPrefix = [2]byte{0x05, 0x44}
+
+// validateRipple validates a classic XRP address or an X-address
+func validateRipple(address string) error {
+	payload, err := base58CheckDecode(address, rippleAlphabet)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(payload) == 21 && payload[0] == 0x00:
+		return nil
+	case len(payload) == 31 && payload[0] == rippleXAddressPrefix[0] && payload[1] == rippleXAddressPrefix[1]:
+		_, _, err = rippleXAddressTag(payload)
+		return err
+	case len(payload) == 21 || len(payload) == 31:
+		return ErrInvalidVersion
+	}
+
+	return ErrInvalidLength
+}
+
+// rippleXAddressTag returns the destination tag embedded in a decoded X-address, and whether it has one
+func rippleXAddressTag(payload []byte) (uint32, bool, error) {
+	flag, tag := payload[22], binary.LittleEndian.Uint64(payload[23:])
+
+	switch {
+	case flag > 1 || tag>>32 != 0:
+		return 0, false, ErrInvalidFormat
+	case flag == 0 && tag != 0:
+		return 0, false, ErrInvalidFormat
+	}
+
+	return uint32(tag), flag == 1, nil
+}
+
+// validateRippleTag validates an XRP destination tag, a 32 bits unsigned integer.
+// An X-address embedding a tag accepts only the same one.
+func validateRippleTag(address string, tag string) error {
+	var value uint64
+
+	if tag != "" {
+		v, err := strconv.ParseUint(tag, 10, 32)
+		if err != nil {
+			return ErrInvalidDestinationTag
+		}
+		value = v
+	}
+
+	payload, err := base58CheckDecode(address, rippleAlphabet)
+	if err != nil || len(payload) != 31 {
+		return nil
+	}
+
+	embedded, ok, err := rippleXAddressTag(payload)
+	if err != nil {
+		return err
+	}
+
+	if ok && tag != "" && uint64(embedded) != value {
+		return ErrInvalidDestinationTag
+	}
+
+	return nil
+}
+
+// validateStellar validates a stellar account (G...) or muxed account (M...) address
+func validateStellar(address string) error {
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(address)
+	if err != nil {
+		return ErrInvalidFormat
+	}
+
+	if len(decoded) < 3 {
+		return ErrInvalidLength
+	}
+
+	payload, checksum := decoded[:len(decoded)-2], decoded[len(decoded)-2:]
+	if crc16XModem(payload) != binary.LittleEndian.Uint16(checksum) {
+		return ErrInvalidChecksum
+	}
+
+	switch payload[0] {
+	case stellarAccountVersion:
+		if len(payload) != 1+32 {
+			return ErrInvalidLength
+		}
+	case stellarMuxedVersion:
+		if len(payload) != 1+32+8 {
+			return ErrInvalidLength
+		}
+	default:
+		return ErrInvalidVersion
+	}
+
+	return nil
+}
+
+// validateStellarMemo validates a stellar memo, a text up to 28 bytes
+func validateStellarMemo(memo string) error {
+	if len(memo) > stellarMaxMemo {
+		return ErrInvalidDestinationTag
+	}
+
+	return nil
+}
+
+// crc16XModem computes the CRC-16/XMODEM checksum of data, used by stellar StrKey
+func crc16XModem(data []byte) uint16 {
+	crc := uint16(0)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
@@ -0,0 +1,182 @@
+// Package validation validates cryptocurrency addresses and destination tags offline, before they are sent to the API.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Networks, as named by the Coinbase API
+const (
+	NetworkBitcoin         = "bitcoin"
+	NetworkBitcoinCash     = "bitcoincash"
+	NetworkLitecoin        = "litecoin"
+	NetworkDogecoin        = "dogecoin"
+	NetworkEthereum        = "ethereum"
+	NetworkEthereumClassic = "ethereum_classic"
+	NetworkBase            = "base"
+	NetworkPolygon         = "polygon"
+	NetworkArbitrum        = "arbitrum"
+	NetworkOptimism        = "optimism"
+	NetworkAvalanche       = "avacchain"
+	NetworkRipple          = "ripple"
+	NetworkStellar         = "stellar"
+	NetworkSolana          = "solana"
+)
+
+// Validation errors, wrapped in an *Error
+var (
+	ErrUnsupportedCurrency      = errors.New("unsupported currency")
+	ErrUnsupportedNetwork       = errors.New("unsupported network")
+	ErrInvalidFormat            = errors.New("invalid format")
+	ErrInvalidChecksum          = errors.New("invalid checksum")
+	ErrInvalidLength            = errors.New("invalid length")
+	ErrInvalidVersion           = errors.New("invalid version, the address may be for another network or a testnet")
+	ErrInvalidDestinationTag    = errors.New("invalid destination tag")
+	ErrUnexpectedDestinationTag = errors.New("network does not support destination tags")
+)
+
+// Error is returned when an address or a destination tag is invalid
+type Error struct {
+	Currency string
+	Network  string
+	Address  string
+	Err      error // One of the validation errors
+}
+
+// Error method implementation for Error struct
+func (e *Error) Error() string {
+	return fmt.Sprintf("validation: %s address %q on %s: %v", e.Currency, e.Address, e.Network, e.Err)
+}
+
+// Unwrap returns the validation error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// networkValidators validates the addresses of each network
+var networkValidators = map[string]func(address string) error{
+	NetworkBitcoin:         validateBitcoin,
+	NetworkBitcoinCash:     validateBitcoinCash,
+	NetworkLitecoin:        validateLitecoin,
+	NetworkDogecoin:        validateDogecoin,
+	NetworkEthereum:        validateEIP55,
+	NetworkEthereumClassic: validateEIP55,
+	NetworkBase:            validateEIP55,
+	NetworkPolygon:         validateEIP55,
+	NetworkArbitrum:        validateEIP55,
+	NetworkOptimism:        validateEIP55,
+	NetworkAvalanche:       validateEIP55,
+	NetworkRipple:          validateRipple,
+	NetworkStellar:         validateStellar,
+	NetworkSolana:          validateSolana,
+}
+
+// currencyNetworks lists the networks each currency can be sent on, the first one is the default
+var currencyNetworks = map[string][]string{
+	"BTC":  {NetworkBitcoin},
+	"BCH":  {NetworkBitcoinCash},
+	"LTC":  {NetworkLitecoin},
+	"DOGE": {NetworkDogecoin},
+	"ETH":  {NetworkEthereum, NetworkBase, NetworkArbitrum, NetworkOptimism},
+	"ETC":  {NetworkEthereumClassic},
+	"XRP":  {NetworkRipple},
+	"XLM":  {NetworkStellar},
+	"SOL":  {NetworkSolana},
+	"USDC": {NetworkEthereum, NetworkBase, NetworkPolygon, NetworkArbitrum, NetworkOptimism, NetworkAvalanche, NetworkSolana, NetworkStellar},
+	"USDT": {NetworkEthereum},
+	"DAI":  {NetworkEthereum},
+	"LINK": {NetworkEthereum},
+	"UNI":  {NetworkEthereum},
+	"AAVE": {NetworkEthereum},
+	"SHIB": {NetworkEthereum},
+	"POL":  {NetworkPolygon, NetworkEthereum},
+}
+
+// resolveNetworks returns the networks the address of currency is validated for: network if set,
+// or every network of the currency, the default one first
+func resolveNetworks(currency string, network string) ([]string, error) {
+	networks, known := currencyNetworks[currency]
+
+	switch {
+	case network == "" && !known:
+		return nil, ErrUnsupportedCurrency
+	case network == "":
+		return networks, nil
+	case networkValidators[network] == nil:
+		return nil, ErrUnsupportedNetwork
+	case !known:
+		// Tokens not listed are accepted on any known network
+		return []string{network}, nil
+	}
+
+	for _, n := range networks {
+		if n == network {
+			return []string{network}, nil
+		}
+	}
+
+	return nil, ErrUnsupportedNetwork
+}
+
+// ValidateAddress validates address for currency on network. When network is empty, the address is accepted
+// if it is valid on any network of the currency, e.g. a Solana USDC address.
+// It returns an *Error wrapping ErrUnsupportedCurrency or ErrUnsupportedNetwork if the address cannot be checked,
+// or the reason why it is invalid.
+func ValidateAddress(currency string, network string, address string) error {
+	return ValidateDestination(currency, network, address, "")
+}
+
+// ValidateDestination validates address and its destination tag or memo, tag is empty if there is none.
+// Tags are only accepted on networks using them: ripple (a 32 bits integer) and stellar (a memo up to 28 bytes).
+// An XRP X-address embeds its tag, a different tag is refused. When network is empty and the address is valid
+// on none of the networks of the currency, the reason reported is the one of the default network.
+func ValidateDestination(currency string, network string, address string, tag string) error {
+	currency = strings.ToUpper(currency)
+	network = strings.ToLower(network)
+
+	networks, err := resolveNetworks(currency, network)
+	if err != nil {
+		return &Error{Currency: currency, Network: network, Address: address, Err: err}
+	}
+
+	var first *Error
+	for _, n := range networks {
+		err := validateOn(n, address, tag)
+		if err == nil {
+			return nil
+		}
+
+		if first == nil {
+			first = &Error{Currency: currency, Network: n, Address: address, Err: err}
+		}
+	}
+
+	return first
+}
+
+// validateOn validates address and tag on network
+func validateOn(network string, address string, tag string) error {
+	if err := networkValidators[network](address); err != nil {
+		return err
+	}
+
+	return validateTag(network, address, tag)
+}
+
+// validateTag validates the destination tag of address on network
+func validateTag(network string, address string, tag string) error {
+	switch network {
+	case NetworkRipple:
+		return validateRippleTag(address, tag)
+	case NetworkStellar:
+		return validateStellarMemo(tag)
+	}
+
+	if tag != "" {
+		return ErrUnexpectedDestinationTag
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+type vector struct {
+	address string
+	err     error // nil for a valid address
+}
+
+func checkVectors(t *testing.T, validate func(string) error, vectors []vector) {
+	t.Helper()
+
+	for _, v := range vectors {
+		err := validate(v.address)
+		if v.err == nil && err != nil {
+			t.Errorf("%s: %v, want valid", v.address, err)
+		}
+		if v.err != nil && !errors.Is(err, v.err) {
+			t.Errorf("%s: %v, want %v", v.address, err, v.err)
+		}
+	}
+}
+
+func TestBase58Check(t *testing.T) {
+	checkVectors(t, validateBitcoin, []vector{
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", nil},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", nil},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", ErrInvalidChecksum},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN0", ErrInvalidFormat},
+		{"LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnJ", ErrInvalidVersion},
+	})
+
+	checkVectors(t, validateLitecoin, []vector{
+		{"LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnJ", nil},
+		{"MJaRnao1s62a2zAKSkmG582KbLKianqb7v", nil},
+		{"LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnK", ErrInvalidChecksum},
+	})
+
+	checkVectors(t, validateDogecoin, []vector{
+		{"DFpN6QqFfUm3gKNaxN6tNcab1FArL9cZLE", nil},
+		{"DFpN6QqFfUm3gKNaxN6tNcab1FArL9cZLF", ErrInvalidChecksum},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", ErrInvalidVersion},
+	})
+
+	checkVectors(t, validateSolana, []vector{
+		{"7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTzXwCFLtV", nil},
+		{"7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTz", ErrInvalidLength},
+		{"0OIl7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKT", ErrInvalidFormat},
+	})
+}
+
+func TestBech32(t *testing.T) {
+	checkVectors(t, validateBitcoin, []vector{
+		// BIP173, Bech32
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", nil},
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", nil},
+		{"bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3", nil},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5", ErrInvalidChecksum},
+		{"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", ErrInvalidVersion},
+		// BIP350, Bech32m
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", nil},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", nil},
+		// Taproot with a Bech32 checksum
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd", ErrInvalidChecksum},
+	})
+}
+
+func TestCashAddr(t *testing.T) {
+	checkVectors(t, validateBitcoinCash, []vector{
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", nil},
+		{"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", nil},
+		{"bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq", nil},
+		// Legacy format of the same key
+		{"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu", nil},
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6b", ErrInvalidFormat},
+		{"bchtest:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", ErrInvalidVersion},
+	})
+}
+
+func TestEIP55(t *testing.T) {
+	checkVectors(t, validateEIP55, []vector{
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", nil},
+		{"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", nil},
+		{"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB", nil},
+		{"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb", nil},
+		// Single case addresses carry no checksum
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", nil},
+		{"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", nil},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", ErrInvalidChecksum},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA", ErrInvalidLength},
+		{"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", ErrInvalidFormat},
+	})
+}
+
+func TestRippleAddresses(t *testing.T) {
+	checkVectors(t, validateRipple, []vector{
+		{"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", nil},
+		{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", nil},
+		// X-addresses, without and with tag
+		{"X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqZ", nil},
+		{"X7AcgcsBL6XDcUb289X4mJ8djcdyKaGZMhc9YTE92ehJ2Fu", nil},
+		{"XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb", nil},
+		// Testnet X-address
+		{"TVE26TYGhfLC7tQDno7G8dGtxSkYQn49b3qD26PK7FcGSKE", ErrInvalidVersion},
+	})
+}
+
+func TestStrKey(t *testing.T) {
+	checkVectors(t, validateStellar, []vector{
+		{"GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", nil},
+		{"GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGA", ErrInvalidChecksum},
+		// Secret seed, not an account
+		{"SCZANGBA5YHTNYVVV4C3U252E2B6P6F5T3U6MM63WBSBZATAQI3EBTQ4", ErrInvalidVersion},
+	})
+}
+
+func TestValidateDestination(t *testing.T) {
+	const xAddressTag1 = "XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC"
+
+	tests := []struct {
+		currency string
+		network  string
+		address  string
+		tag      string
+		err      error
+	}{
+		{"BTC", "", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "", nil},
+		{"btc", "BITCOIN", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "", nil},
+		{"BTC", "", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "1", ErrUnexpectedDestinationTag},
+		{"BTC", "ethereum", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "", ErrUnsupportedNetwork},
+		{"XYZ", "", "whatever", "", ErrUnsupportedCurrency},
+		{"XYZ", "ethereum", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "", nil},
+		// USDC without network is accepted on any of its networks
+		{"USDC", "", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "", nil},
+		{"USDC", "", "7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTzXwCFLtV", "", nil},
+		{"USDC", "", "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", "memo", nil},
+		{"USDC", "", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", "", ErrInvalidChecksum},
+		{"USDC", "solana", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "", ErrInvalidFormat},
+		// Destination tags
+		{"XRP", "", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "12345", nil},
+		{"XRP", "", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "4294967296", ErrInvalidDestinationTag},
+		{"XRP", "", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "abc", ErrInvalidDestinationTag},
+		{"XRP", "", xAddressTag1, "", nil},
+		{"XRP", "", xAddressTag1, "1", nil},
+		{"XRP", "", xAddressTag1, "2", ErrInvalidDestinationTag},
+		{"XLM", "", "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", "12345678901234567890123456789", ErrInvalidDestinationTag},
+	}
+
+	for _, test := range tests {
+		err := ValidateDestination(test.currency, test.network, test.address, test.tag)
+		if test.err == nil && err != nil {
+			t.Errorf("%s on %q %s tag %q: %v, want valid", test.currency, test.network, test.address, test.tag, err)
+		}
+		if test.err != nil && !errors.Is(err, test.err) {
+			t.Errorf("%s on %q %s tag %q: %v, want %v", test.currency, test.network, test.address, test.tag, err, test.err)
+		}
+	}
+
+	var validationErr *Error
+	if err := ValidateAddress("USDC", "", "0xnothex"); !errors.As(err, &validationErr) || validationErr.Network != NetworkEthereum {
+		t.Errorf("error of an address invalid on every network = %v, want the default network reported", err)
+	}
+}
@@ -2,17 +2,18 @@ package coinbase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
 // ListWithdrawals Lists withdrawals for an account.
 // Endpoint: GET /accounts/:account_id/withdrawals
-func (c *Client) ListWithdrawals(ctx context.Context, accountID string) (*[]Withdrawal, *Pagination, error) {
+func (c *Client) ListWithdrawals(ctx context.Context, accountID string, opts *ListOptions) (*[]Withdrawal, *Pagination, error) {
 	withdrawals := &[]Withdrawal{}
 
 	pagination := &Pagination{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "withdrawals"), nil)
+	req, err := c.NewRequest(ctx, "GET", withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "withdrawals"), opts), nil)
 	if err != nil {
 		return withdrawals, pagination, err
 	}
@@ -24,12 +25,35 @@ func (c *Client) ListWithdrawals(ctx context.Context, accountID string) (*[]With
 	return withdrawals, pagination, nil
 }
 
+// IterateWithdrawals Returns an Iterator over all withdrawals for an account, following pagination automatically.
+// Every Value is a Withdrawal.
+func (c *Client) IterateWithdrawals(ctx context.Context, accountID string, opts *ListOptions) *Iterator {
+	return c.newIterator(ctx, withListOptions(fmt.Sprintf("%s/%s/%s/%s", c.APIBase, "accounts", accountID, "withdrawals"), opts), func(data []byte) (interface{}, error) {
+		withdrawal := Withdrawal{}
+		err := json.Unmarshal(data, &withdrawal)
+		return withdrawal, err
+	})
+}
+
+// ListAllWithdrawals Lists all withdrawals for an account, fetching every page.
+func (c *Client) ListAllWithdrawals(ctx context.Context, accountID string, opts *ListOptions) (*[]Withdrawal, error) {
+	withdrawals := &[]Withdrawal{}
+
+	it := c.IterateWithdrawals(ctx, accountID, opts)
+	for it.Next() {
+		*withdrawals = append(*withdrawals, it.Value().(Withdrawal))
+	}
+
+	return withdrawals, it.Err()
+}
+
 // GetWithdrawal Show an individual withdrawal.
+// Related resources listed in expand, e.g. ExpandAll, are embedded in the response.
 // Endpoint: GET /accounts/:account_id/withdrawals/:withdrawal_id
-func (c *Client) GetWithdrawal(ctx context.Context, accountID string, withdrawalID string) (*Withdrawal, error) {
+func (c *Client) GetWithdrawal(ctx context.Context, accountID string, withdrawalID string, expand ...string) (*Withdrawal, error) {
 	withdrawal := &Withdrawal{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "withdrawals", withdrawalID), nil)
+	req, err := c.NewRequest(ctx, "GET", withExpand(fmt.Sprintf("%s/%s/%s/%s/%s", c.APIBase, "accounts", accountID, "withdrawals", withdrawalID), expand), nil)
 	if err != nil {
 		return withdrawal, err
 	}
@@ -51,7 +75,8 @@ func (c *Client) Withdraw(ctx context.Context, accountID string, withdrawData Wi
 		return withdrawal, err
 	}
 
-	if err = c.SendWithAuth(req, withdrawal); err != nil {
+	action := PolicyAction{Operation: TransactionTypeFiatWithdrawal, AccountID: accountID, Amount: Money{Amount: withdrawData.Amount, Currency: withdrawData.Currency}}
+	if err = c.guard(ctx, action, func() error { return c.SendWithAuth(req, withdrawal) }); err != nil {
 		return withdrawal, err
 	}
 